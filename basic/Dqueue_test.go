@@ -1,6 +1,7 @@
 package basic
 
 import (
+	"errors"
 	"fmt"
 	"math/rand/v2"
 	"slices"
@@ -8,78 +9,6 @@ import (
 	"time"
 )
 
-type Deque[T any] interface {
-	InsertFront(item T)
-	InsertBack(item T)
-	RemoveFirst() T
-	RemoveLast() T
-	First() T
-	Last() T
-	IsEmpty() bool
-	Size() int
-}
-
-type SliceDeque[T any] struct {
-	items []T
-}
-
-func (sdq *SliceDeque[T]) InsertFront(item T) {
-	if IsNil(item) {
-		panic("空值不允许插入到队列")
-	}
-	sdq.items = append(sdq.items, item)
-	l := len(sdq.items)
-	for i := l - 1; i > 0; i-- {
-		sdq.items[i] = sdq.items[i-1]
-	}
-	sdq.items[0] = item
-}
-func (sdq *SliceDeque[T]) InsertBack(item T) {
-	if IsNil(item) {
-		panic("空值不允许插入到队列")
-	}
-	sdq.items = append(sdq.items, item)
-}
-func (sdq *SliceDeque[T]) RemoveFirst() T {
-	if len(sdq.items) == 0 {
-		panic("队列已空，不能再删除元素")
-	}
-	result := sdq.items[0]
-	sdq.items = sdq.items[1:]
-	return result
-
-}
-func (sdq *SliceDeque[T]) RemoveLast() T {
-	l := len(sdq.items)
-	if l == 0 {
-		panic("队列已空，不能再删除元素")
-	}
-	result := sdq.items[l-1]
-	sdq.items = sdq.items[0 : l-1]
-	return result
-
-}
-func (sdq *SliceDeque[T]) First() T {
-	if len(sdq.items) == 0 {
-		panic("队列已空，无法读取第一个元素")
-	}
-	return sdq.items[0]
-}
-func (sdq *SliceDeque[T]) Last() T {
-	if len(sdq.items) == 0 {
-		panic("队列已空，无法读取最后一个元素")
-	}
-	l := len(sdq.items)
-	return sdq.items[l-1]
-}
-func (sdq *SliceDeque[T]) IsEmpty() bool {
-	return len(sdq.items) == 0
-
-}
-func (sdq *SliceDeque[T]) Size() int {
-	return len(sdq.items)
-}
-
 func TestDeque(t *testing.T) {
 	var myDeque Deque[int] = &SliceDeque[int]{}
 	myDeque.InsertFront(5)
@@ -179,7 +108,12 @@ func MaxSubarryBruteForceImprove(input []int, k int) (output []int) {
 // !!! 或者用于从队尾“窃取（获取然后移除）”的操作。
 
 func MaxSubarrayUsingDeque(input []int, k int) (output []int) {
-	deque := SliceDeque[int]{}
+	return maxSubarrayOnDeque(&SliceDeque[int]{}, input, k)
+}
+
+// maxSubarrayOnDeque是MaxSubarrayUsingDeque的算法本身，它只用到Deque[int]接口的操作，
+// 因此可以运行在SliceDeque、RingDeque或LinkedDeque上。
+func maxSubarrayOnDeque(deque Deque[int], input []int, k int) (output []int) {
 	var index int
 	// 第一个元素窗口 [0,k]
 	for index = 0; index < k; index++ {
@@ -315,3 +249,196 @@ func TestComparePerformance(t *testing.T) {
 	fmt.Println("Using Brute Force: ", elapsed)
 
 }
+
+func TestDequeErrVariants(t *testing.T) {
+	deque := SliceDeque[int]{}
+	if _, err := deque.RemoveFirstErr(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("空队列RemoveFirstErr应返回ErrEmpty，实际为%v", err)
+	}
+	if _, err := deque.RemoveLastErr(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("空队列RemoveLastErr应返回ErrEmpty，实际为%v", err)
+	}
+	if _, ok := deque.TryLast(); ok {
+		t.Errorf("空队列TryLast应返回false")
+	}
+	deque.InsertBackErr(2)
+	deque.InsertFrontErr(1)
+	deque.InsertBackErr(3) // 1 2 3
+	if first, ok := deque.TryFirst(); !ok || first != 1 {
+		t.Errorf("TryFirst应返回(1,true)，实际为(%d,%v)", first, ok)
+	}
+	if last, err := deque.LastErr(); err != nil || last != 3 {
+		t.Errorf("LastErr应返回(3,nil)，实际为(%d,%v)", last, err)
+	}
+	if item, ok := deque.TryRemoveLast(); !ok || item != 3 {
+		t.Errorf("TryRemoveLast应返回(3,true)，实际为(%d,%v)", item, ok)
+	}
+	if item, ok := deque.TryRemoveFirst(); !ok || item != 1 {
+		t.Errorf("TryRemoveFirst应返回(1,true)，实际为(%d,%v)", item, ok)
+	}
+	var nilDeque SliceDeque[[]int]
+	if err := nilDeque.InsertFrontErr(nil); !errors.Is(err, ErrNilValue) {
+		t.Errorf("nil值InsertFrontErr应返回ErrNilValue，实际为%v", err)
+	}
+}
+
+// dequeTypes是以不同存储结构实现的双端队列，newDeque创建一个空队列
+var dequeTypes = []struct {
+	name     string
+	newDeque func() Deque[int]
+}{
+	{"SliceDeque", func() Deque[int] { return &SliceDeque[int]{} }},
+	{"RingDeque", func() Deque[int] { return &RingDeque[int]{} }},
+	{"LinkedDeque", func() Deque[int] { return &LinkedDeque[int]{} }},
+}
+
+// !!! 比较各种双端队列在队头插入元素时的性能，SliceDeque的InsertFront需要移动所有元素
+func BenchmarkDequeInsertFront(b *testing.B) {
+	const dequeSize = 1000
+	for _, d := range dequeTypes {
+		b.Run(d.name, func(b *testing.B) {
+			deque := d.newDeque()
+			for i := 0; i < b.N; i++ {
+				if deque.Size() == dequeSize {
+					deque.RemoveLast()
+				}
+				deque.InsertFront(i)
+			}
+		})
+	}
+}
+
+func TestMaxSubarrayOnAllDeques(t *testing.T) {
+	input := make([]int, 10000)
+	for i := range input {
+		input[i] = rand.IntN(100)
+	}
+	for _, k := range []int{1, 3, 50} {
+		want := MaxSubarryBruteForce(input, k)
+		for _, dt := range dequeTypes {
+			if got := maxSubarrayOnDeque(dt.newDeque(), input, k); !slices.Equal(got, want) {
+				t.Errorf("%s, k=%d: 结果与暴力算法不一致", dt.name, k)
+			}
+		}
+	}
+}
+
+// !!! 以随机的操作对比各种双端队列与以切片模拟的模型的结果，覆盖按序号读写、循环移动、批量插入、反转与复制
+func TestDequeRichAPI(t *testing.T) {
+	for _, dt := range dequeTypes {
+		name, d := dt.name, dt.newDeque()
+		var model []int
+		for step := range 20000 {
+			switch rand.IntN(8) {
+			case 0:
+				d.InsertFront(step)
+				model = slices.Insert(model, 0, step)
+			case 1:
+				d.InsertBack(step)
+				model = append(model, step)
+			case 2:
+				if len(model) > 0 {
+					i := rand.IntN(len(model))
+					d.Set(i, -step)
+					model[i] = -step
+				}
+			case 3:
+				k := rand.IntN(41) - 20
+				d.Rotate(k)
+				if n := len(model); n > 0 {
+					k = (k%n + n) % n
+					model = append(model[n-k:], model[:n-k]...)
+				}
+			case 4:
+				items := []int{step, step + 1, step + 2}
+				d.PushFrontAll(items...)
+				model = append(slices.Clone(items), model...)
+			case 5:
+				d.PushBackAll(step, step+1)
+				model = append(model, step, step+1)
+			case 6:
+				d.Reverse()
+				slices.Reverse(model)
+			case 7:
+				//!!! 每步平均插入7/8个元素、移除4/8个元素，队列缓慢增长，不会反复回到空队列
+				for range 2 {
+					if len(model) < 2 {
+						break
+					}
+					d.RemoveFirst()
+					d.RemoveLast()
+					model = model[1 : len(model)-1]
+				}
+			}
+			if d.Size() != len(model) {
+				t.Fatalf("%s: 第%d步元素个数应为%d，实际为%d", name, step, len(model), d.Size())
+			}
+			if len(model) > 0 && (d.First() != model[0] || d.Last() != model[len(model)-1]) {
+				t.Fatalf("%s: 第%d步队头队尾应为%d,%d，实际为%d,%d",
+					name, step, model[0], model[len(model)-1], d.First(), d.Last())
+			}
+		}
+		if len(model) < 1000 {
+			t.Fatalf("%s: 随机操作之后只有%d个元素，没有覆盖足够长的队列", name, len(model))
+		}
+		for i, x := range model {
+			if d.At(i) != x {
+				t.Fatalf("%s: 第%d个元素应为%d，实际为%d", name, i, x, d.At(i))
+			}
+		}
+
+		//!!! 副本与原队列互不影响；先追加已知的元素，保证Set(0, ...)不会越界
+		d.PushBackAll(1, 2, 3)
+		model = append(model, 1, 2, 3)
+		c := d.Clone()
+		c.Set(0, 12345)
+		c.InsertBack(6789)
+		if d.At(0) == 12345 || d.Size() != len(model) || c.Size() != len(model)+1 {
+			t.Errorf("%s: 修改副本影响了原队列", name)
+		}
+		d.Clear()
+		if !d.IsEmpty() || c.IsEmpty() {
+			t.Errorf("%s: Clear之后原队列应为空，副本不受影响", name)
+		}
+		d.PushBackAll(1, 2, 3)
+		d.Rotate(-1)
+		if d.At(0) != 2 || d.At(1) != 3 || d.At(2) != 1 {
+			t.Errorf("%s: Rotate(-1)的结果应为[2 3 1]，实际为[%d %d %d]", name, d.At(0), d.At(1), d.At(2))
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: At(3)应抛出panic", name)
+				}
+			}()
+			d.At(3)
+		}()
+	}
+}
+
+func TestDequeBulkInsertPolicy(t *testing.T) {
+	deques := map[string]Deque[int]{
+		"SliceDeque":  Deque[int](ptr(NewSliceDeque[int](WithNilPolicy(RejectZero)))),
+		"RingDeque":   Deque[int](ptr(NewRingDeque[int](WithNilPolicy(RejectZero)))),
+		"LinkedDeque": Deque[int](ptr(NewLinkedDeque[int](WithNilPolicy(RejectZero)))),
+	}
+	for name, d := range deques {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: 批量插入零值应抛出panic", name)
+				}
+				//!!! 批量插入是原子的：有元素被拒绝时一个元素也不插入
+				if d.Size() != 0 {
+					t.Errorf("%s: 批量插入失败后队列应为空，实际有%d个元素", name, d.Size())
+				}
+			}()
+			d.PushBackAll(1, 2, 0, 3)
+		}()
+	}
+}
+
+// ptr返回指向v的副本的指针，用于把构造函数返回的值转换为实现接口的指针
+func ptr[T any](v T) *T {
+	return &v
+}
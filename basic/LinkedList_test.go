@@ -1,153 +1,13 @@
 package basic
 
 import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
 	"testing"
 )
 
-type List[T any] interface {
-	First() T             //Returns the first node in the list
-	Size() int            //Returns the number of nodes in the list
-	Insert(i int, item T) //Creates and inserts item in the ith node of the list
-	RemoveAt(i int) T     //Removes and returns the item in the ith node of the list
-	Append(item T)        //Creates and inserts item into the last node of the list
-	Get(i int) T          // Returns the node position containing item in the list
-	Items() []T           //Returns a slice of all the items in the list
-}
-
-// !!! 这个函数来自于go 1.21.0 开始发布的slices包。
-// !!! SingleLinkedList[T any] 给出了任何类型的列表容器的通用操作，无法给出针对可比较类型（comparable）元素
-// !!! 的查找（Find）操作，也无法给出可排序类型（ordered)元素的找到最大值(Max)、最小值(Min、排序(Sort)等操作.
-// !!! 故而根据 [T any]可能是comparable或ordered类型，给出相应的辅助数据与行为分离的函数式编程思想的运用，即，
-// !!! 根据数据类型的共性特征（由接口所代表的方法集）来给出独立的操作函数。这样可解决面向对象编程思想中的一些约束问题，
-// !!! 比如，强制要求所操作元素的类型必须拥有特定的特征，比如要求元素必须是可比较（comparable）的或可排序的（ordered）。
-func Find[E comparable](l List[E], e E) int {
-	result := -1
-	for index := 0; index < l.Size(); index++ {
-		if l.Get(index) == e {
-			result = index
-			break
-		}
-	}
-	return result
-}
-
-// ///////////////////////////////////以下是单向列表的操作
-// 包外不可见
-
-type SingleLinkedList[T any] struct {
-	head *Node[T]
-	tail *Node[T] //方便Append操作，即，在尾部追加节点,通常的单向链表没有这个节点
-	size int
-}
-
-func (sll *SingleLinkedList[T]) Size() int {
-	return sll.size
-}
-func (sll *SingleLinkedList[T]) IsEmpty() bool {
-	return sll.size == 0
-}
-
-func (sll SingleLinkedList[T]) First() T {
-	return sll.head.value
-}
-func (sll SingleLinkedList[T]) Items() []T {
-	result := []T{}
-	for node := sll.head; node.next != nil; node = node.next {
-		result = append(result, node.value)
-	}
-	return result
-
-}
-func (sll *SingleLinkedList[T]) addNodeToEmpyList(nd *Node[T]) {
-	if sll.size > 0 {
-		panic("列表不空，操作错误")
-	}
-	sll.head = nd
-	sll.tail = nd
-	sll.size++
-}
-func (sll *SingleLinkedList[T]) Append(value T) {
-	nd := &Node[T]{
-		value: value,
-		next:  nil,
-	}
-	if sll.size == 0 {
-		sll.addNodeToEmpyList(nd)
-		return
-	}
-	sll.tail.next = nd
-	sll.tail = nd
-	sll.size++
-
-}
-func (sll *SingleLinkedList[T]) Insert(i int, value T) {
-	newNd := &Node[T]{value: value, next: nil}
-	if sll.size == 0 {
-		sll.addNodeToEmpyList(newNd)
-		return
-	}
-	position := i
-	if position < 0 {
-		position = 0
-	} else if position > sll.size {
-		position = sll.size
-	}
-	if position == 0 {
-		oldHead := sll.head
-		sll.head = newNd
-		newNd.next = oldHead
-		sll.size++
-		return
-	}
-	if position == sll.size {
-		sll.tail.next = newNd
-		sll.tail = newNd
-		sll.size++
-		return
-	}
-	preNode := sll.getNode(position - 1)
-	curNodeAtPosition := sll.getNode(position)
-	preNode.next = newNd
-	newNd.next = curNodeAtPosition
-	sll.size++
-}
-func (sll *SingleLinkedList[T]) RemoveAt(i int) T {
-	if sll.IsEmpty() {
-		panic("试图从空列表中删除元素")
-	}
-	var ndTobeDelete *Node[T]
-	if i == 0 {
-		ndTobeDelete = sll.head
-		sll.head = ndTobeDelete.next
-		sll.size--
-		return ndTobeDelete.value
-	}
-	preNode := sll.getNode(i - 1)
-	ndTobeDelete = sll.getNode(i)
-	preNode.next = ndTobeDelete.next
-	sll.size--
-	return ndTobeDelete.value
-}
-func (sll *SingleLinkedList[T]) getNode(i int) *Node[T] {
-	if i < 0 || i >= sll.size || sll.IsEmpty() {
-		panic("无法获取非法序号的节点")
-	}
-	index := 0
-	nd := sll.head
-	for {
-		if index == i {
-			return nd
-		} else {
-			nd = nd.next
-			index++
-		}
-	}
-}
-func (sll *SingleLinkedList[T]) Get(i int) T {
-	nd := sll.getNode(i)
-	return nd.value
-}
-
 func TestSingleLinkedList(t *testing.T) {
 	var slli List[int] = &SingleLinkedList[int]{}
 	slli.Append(1)
@@ -169,159 +29,6 @@ func TestSingleLinkedList(t *testing.T) {
 	println(Find(slls, "Two"))
 }
 
-type DoubleLinkedList[T any] struct {
-	head *DNode[T]
-	tail *DNode[T]
-	size int
-}
-
-func (dll DoubleLinkedList[T]) First() T { //Returns the first node in the list
-
-	return dll.head.value
-}
-
-func (dll DoubleLinkedList[T]) Size() int { //Returns the number of nodes in the list
-	return dll.size
-}
-func (dll *DoubleLinkedList[T]) addNodeToEmpyList(nd *DNode[T]) {
-	if dll.size != 0 {
-		panic("列表不空，操作错误")
-	}
-	dll.head = nd
-	dll.tail = nd
-	dll.size += 1
-}
-func (dll *DoubleLinkedList[T]) doInsert(oldNode, nd *DNode[T]) {
-	if oldNode == nil {
-		panic("插入所在位置的节点不存在")
-	}
-	preNode := oldNode.pre
-	preNode.next = nd
-	nd.pre = preNode
-	nd.next = oldNode
-	oldNode.pre = nd
-	dll.size += 1
-}
-func (dll *DoubleLinkedList[T]) Insert(i int, item T) { //Creates and inserts item in the ith node of the list
-	if IsNil(item) {
-		panic("不允许向列表插入空对象！")
-	}
-
-	nd := &DNode[T]{value: item, pre: nil, next: nil}
-	if dll.size == 0 {
-		dll.addNodeToEmpyList(nd)
-		return
-	}
-	if i < 0 {
-		dll.doInsert(dll.head, nd)
-		return
-	}
-	if i >= dll.size {
-		dll.Append(item)
-		return
-	}
-	if i >= dll.size/2 {
-		oldNode := dll.tail
-		for index := dll.size - 1; index > i; index-- {
-			oldNode = oldNode.pre
-		}
-		dll.doInsert(oldNode, nd)
-	} else {
-		oldNode := dll.head
-		for index := 0; index < i; index++ {
-			oldNode = oldNode.next
-		}
-		dll.doInsert(oldNode, nd)
-	}
-}
-func (dll *DoubleLinkedList[T]) doRemoveNode(node *DNode[T]) T {
-	dll.size -= 1
-	preNode := node.pre
-	nextNode := node.next
-	if preNode == nil {
-		dll.head = nextNode
-		return node.value
-	}
-	if nextNode == nil {
-		dll.tail = node.pre
-		return node.value
-	}
-	preNode.next = nextNode
-	nextNode.pre = preNode
-	return node.value
-}
-func (dll *DoubleLinkedList[T]) RemoveAt(i int) T { //Removes and returns the item in the ith node of the list
-	if i < 0 || i >= dll.size {
-		panic("给定的元素位置超界")
-	}
-	if i >= dll.size/2 {
-		node := dll.tail
-		for index := dll.size - 1; index > i; index-- {
-			node = node.pre
-		}
-		return dll.doRemoveNode(node)
-	} else {
-		node := dll.head
-		for index := 0; index < i; index++ {
-			node = node.next
-		}
-		return dll.doRemoveNode(node)
-	}
-}
-
-func (dll *DoubleLinkedList[T]) Append(item T) { //Creates and inserts item into the last node of the list
-	if IsNil(item) {
-		panic("不允许向列表插入空对象！")
-	}
-	nd := &DNode[T]{value: item, pre: nil, next: nil}
-	if dll.size == 0 {
-		dll.addNodeToEmpyList(nd)
-		return
-	}
-	nd.pre = dll.tail
-	dll.tail.next = nd
-	dll.tail = nd
-	dll.size += 1
-}
-
-// 双向链表获取制定位置的元素可以根据位置是靠近头节点还是尾结点来进行一些优化
-func (dll DoubleLinkedList[T]) Get(i int) T { // Returns the node position containing item in the list
-	if i < 0 || i >= dll.size || dll.size == 0 {
-		panic("无法获取非法序号的节点")
-	}
-	if i <= dll.size/2 {
-		index := 0
-		nd := dll.head
-		for {
-			if index == i {
-				return nd.value
-			} else {
-				nd = nd.next
-				index++
-			}
-		}
-	} else {
-		index := dll.size - 1
-		nd := dll.tail
-		for {
-			if index == i {
-				return nd.value
-			}
-			nd = nd.pre
-			index--
-		}
-	}
-}
-func (dll DoubleLinkedList[T]) Items() []T { //Returns a slice of all the items in the list
-	var result []T
-	if dll.size == 0 {
-		return result
-	}
-	for nd := dll.head; nd.next != nil; nd = nd.next {
-		result = append(result, nd.value)
-	}
-	return result
-}
 func TestDoubleLinkedList(t *testing.T) {
 	TestSingleLinkedList(t)
 	println("-------------------------------------------------")
@@ -350,3 +57,166 @@ func TestDoubleLinkedList(t *testing.T) {
 	println(Find(sdll, "Five"))
 
 }
+
+// listTypes是以不同链表实现的列表，newList创建一个空列表
+var listTypes = []struct {
+	name    string
+	newList func() List[int]
+}{
+	{"SingleLinkedList", func() List[int] { return &SingleLinkedList[int]{} }},
+	{"DoubleLinkedList", func() List[int] { return &DoubleLinkedList[int]{} }},
+}
+
+// checkList比较列表与模型，同时从头（Items）和尾（Last、Backward）两个方向检查链接是否正确
+func checkList(t *testing.T, name string, l List[int], model []int) {
+	t.Helper()
+	if got := l.Items(); !slices.Equal(got, model) || l.Size() != len(model) {
+		t.Fatalf("%s: 元素为%v（Size=%d），应为%v", name, got, l.Size(), model)
+	}
+	if len(model) > 0 && (l.First() != model[0] || l.Last() != model[len(model)-1]) {
+		t.Fatalf("%s: 首尾元素为%d,%d，应为%d,%d", name, l.First(), l.Last(), model[0], model[len(model)-1])
+	}
+	if dll, ok := l.(*DoubleLinkedList[int]); ok {
+		reversed := slices.Clone(model)
+		slices.Reverse(reversed)
+		if got := slices.Collect(dll.Backward()); !slices.Equal(got, reversed) {
+			t.Fatalf("%s: 反向遍历的结果为%v", name, got)
+		}
+	}
+}
+
+func TestListOperations(t *testing.T) {
+	for _, lt := range listTypes {
+		name, l := lt.name, lt.newList()
+		checkList(t, name, l, []int{}) //!!! 空列表的Items不应panic
+		for _, x := range []int{5, 3, 8, 3, 1} {
+			l.Append(x)
+		}
+		l.Prepend(9)
+		model := []int{9, 5, 3, 8, 3, 1}
+		checkList(t, name, l, model) //!!! Items包含最后一个节点
+
+		l.Set(2, 7)
+		model[2] = 7
+		checkList(t, name, l, model)
+		if IndexOf(l, 3) != 4 || IndexOf(l, 42) != -1 || !Contains(l, 9) || Contains(l, 42) {
+			t.Errorf("%s: IndexOf或Contains的结果不正确", name)
+		}
+
+		sub := l.Sublist(1, 4)
+		checkList(t, name+".Sublist", sub, []int{5, 7, 8})
+		sub.Set(0, 100) //!!! 子列表是副本，修改它不影响原列表
+		checkList(t, name, l, model)
+		checkList(t, name+".Sublist", l.Sublist(6, 6), []int{})
+
+		l.Reverse()
+		slices.Reverse(model)
+		checkList(t, name, l, model)
+
+		l.SortFunc(cmp.Compare[int])
+		slices.Sort(model)
+		checkList(t, name, l, model)
+		l.Append(0) //!!! 排序之后尾节点仍然正确
+		model = append(model, 0)
+		checkList(t, name, l, model)
+
+		isOdd := func(x int) bool { return x%2 != 0 }
+		if n := l.RemoveFunc(isOdd); n != 5 {
+			t.Errorf("%s: RemoveFunc应删除5个元素，实际删除了%d个", name, n)
+		}
+		model = slices.DeleteFunc(model, isOdd)
+		checkList(t, name, l, model)
+		l.Append(2)
+		model = append(model, 2)
+		checkList(t, name, l, model)
+		if n := l.RemoveFunc(func(int) bool { return true }); n != len(model) {
+			t.Errorf("%s: 应删除全部%d个元素，实际删除了%d个", name, len(model), n)
+		}
+		checkList(t, name, l, []int{})
+		l.Append(1)
+		l.Clear()
+		checkList(t, name, l, []int{})
+		l.Prepend(4)
+		checkList(t, name, l, []int{4})
+	}
+}
+
+func TestListSortFunc(t *testing.T) {
+	for _, lt := range listTypes {
+		for _, n := range []int{0, 1, 2, 3, 100, 1000} {
+			l := lt.newList()
+			model := make([]int, n)
+			for i := range model {
+				model[i] = rand.IntN(20)*1000 + i //!!! 以千位比较，低位记录原始顺序，用于检查稳定性
+				l.Append(model[i])
+			}
+			byKey := func(a, b int) int { return cmp.Compare(a/1000, b/1000) }
+			l.SortFunc(byKey)
+			slices.SortStableFunc(model, byKey)
+			checkList(t, fmt.Sprintf("%s(n=%d)", lt.name, n), l, model)
+		}
+	}
+}
+
+func TestListModCount(t *testing.T) {
+	for _, lt := range listTypes {
+		name, l := lt.name, lt.newList()
+		for i := range 5 {
+			l.Append(i)
+		}
+		for _, modify := range []func(){
+			func() { l.Reverse() },
+			func() { l.SortFunc(cmp.Compare[int]) },
+			func() { l.RemoveFunc(func(x int) bool { return x == 2 }) },
+			func() { l.Prepend(7) },
+			func() { l.Clear(); l.Append(1); l.Append(2) },
+		} {
+			func() {
+				defer func() {
+					if r := recover(); r != ErrConcurrentModification {
+						t.Errorf("%s: 迭代过程中修改列表应抛出ErrConcurrentModification，实际为%v", name, r)
+					}
+				}()
+				for range l.All() {
+					modify()
+				}
+			}()
+		}
+		//!!! Set不是结构修改
+		for i := range l.All() {
+			l.Set(0, i)
+		}
+	}
+}
+
+// getCountingList记录Get被调用的次数，其余方法直接使用被包装的列表
+type getCountingList[T any] struct {
+	List[T]
+	gets int
+}
+
+func (l *getCountingList[T]) Get(i int) T {
+	l.gets++
+	return l.List.Get(i)
+}
+
+func TestFindIsLinear(t *testing.T) {
+	//!!! 旧的Find在循环中调用Get，而链表的每次Get都要从头移动到第i个节点，整个查找是O(n²)的；
+	//!!! 顺序遍历的查找不应调用Get
+	for _, lt := range listTypes {
+		l := &getCountingList[int]{List: lt.newList()}
+		const n = 1000
+		for i := range n {
+			l.Append(i)
+		}
+		if i := Find[int](l, n-1); i != n-1 {
+			t.Errorf("%s: Find的结果为%d，应为%d", lt.name, i, n-1)
+		}
+		if IndexOf[int](l, n) != -1 || !Contains[int](l, 0) {
+			t.Errorf("%s: IndexOf或Contains的结果不正确", lt.name)
+		}
+		if l.gets != 0 {
+			t.Errorf("%s: 查找调用了%d次Get，应按顺序遍历节点", lt.name, l.gets)
+		}
+	}
+}
@@ -1,63 +1,12 @@
 package basic
 
 import (
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"testing"
 )
 
-type PriorityQueue[T any] struct {
-	q    []SliceQueue[T] // 队列的切片,切片的序号（index）表示所含队列元素的优先级。
-	size int
-}
-
-func NewPriorityQueue[T any](numberPriorities int) (pq PriorityQueue[T]) {
-	pq.q = make([]SliceQueue[T], numberPriorities)
-	return pq
-	/** 上面的代码等价于以下代码
-	pq = PriorityQueue[T]{
-		q:    make([]SliceQueue[T], numberPriorities),
-		size: 0}
-	**/
-}
-
-func (pq *PriorityQueue[T]) Insert(item T, priority int) {
-	pq.q[priority-1].Insert(item)
-	pq.size++
-}
-
-func (pq *PriorityQueue[T]) Remove() T {
-	if pq.size == 0 {
-		panic("队列已空，不能再移除元素")
-	}
-	var result T
-	for i := 0; i < len(pq.q); i++ {
-		if pq.q[i].Size() > 0 {
-			result = pq.q[i].Remove()
-			pq.size--
-			break
-		}
-	}
-	return result
-}
-
-func (pq *PriorityQueue[T]) First() T {
-	if pq.size == 0 {
-		panic("队列已空，无法获得头元素")
-	}
-	var result T
-	for i := 0; i < len(pq.q); i++ {
-		q := pq.q[i]
-		if q.Size() > 0 {
-			result = q.First()
-			break
-		}
-	}
-	return result
-}
-func (pq *PriorityQueue[T]) IsEmpty() bool {
-	return pq.size == 0
-}
-
 type Passenger struct {
 	name     string
 	priority int
@@ -79,3 +28,107 @@ func TestPriorityQueue(t *testing.T) {
 	fmt.Println("First passenger in line: ", airlineQueue.First())
 
 }
+
+func TestPriorityQueueErrVariants(t *testing.T) {
+	pq := NewPriorityQueue[string](2)
+	if _, err := pq.RemoveErr(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("空队列RemoveErr应返回ErrEmpty，实际为%v", err)
+	}
+	if err := pq.InsertErr("Erika", 3); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("超界优先级InsertErr应返回ErrOutOfRange，实际为%v", err)
+	}
+	pq.InsertErr("Robert", 2)
+	pq.InsertErr("Madison", 1)
+	if first, ok := pq.TryFirst(); !ok || first != "Madison" {
+		t.Errorf("TryFirst应返回(Madison,true)，实际为(%s,%v)", first, ok)
+	}
+	if item, ok := pq.TryRemove(); !ok || item != "Madison" {
+		t.Errorf("TryRemove应返回(Madison,true)，实际为(%s,%v)", item, ok)
+	}
+	if item, err := pq.RemoveErr(); err != nil || item != "Robert" {
+		t.Errorf("RemoveErr应返回(Robert,nil)，实际为(%s,%v)", item, err)
+	}
+	if _, ok := pq.TryRemove(); ok {
+		t.Errorf("空队列TryRemove应返回false")
+	}
+}
+
+func TestHeapPriorityQueue(t *testing.T) {
+	airlineQueue := NewHeapPriorityQueue[Passenger, int]()
+	passengers := []Passenger{{"Erika", 3}, {"Robert", 3}, {"Danielle", 3},
+		{"Madison", 1}, {"Frederik", 1}, {"James", 2},
+		{"Dante", 2}, {"Shelley", 3}}
+	for _, p := range passengers {
+		airlineQueue.Insert(p, p.priority)
+	}
+	//!!! 同一优先级的乘客按照先进先出的顺序排队，结果应与PriorityQueue相同
+	want := []string{"Madison", "Frederik", "James", "Dante", "Erika", "Robert", "Danielle", "Shelley"}
+	for i, name := range want {
+		if got := airlineQueue.Remove().name; got != name {
+			t.Fatalf("第%d个移除的乘客应为%s，实际为%s", i, name, got)
+		}
+	}
+	if !airlineQueue.IsEmpty() {
+		t.Errorf("队列应为空")
+	}
+	if _, err := airlineQueue.RemoveErr(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("空队列RemoveErr应返回ErrEmpty，实际为%v", err)
+	}
+}
+
+func TestHeapPriorityQueueFuncAndUpdate(t *testing.T) {
+	//!!! 自定义less函数：优先级数值越大越优先
+	pq := NewHeapPriorityQueueFunc[string](func(a, b float64) bool { return a > b })
+	handles := map[string]*PQHandle[string, float64]{}
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		handles[name] = pq.Insert(name, float64(i))
+	}
+	if first := pq.First(); first != "e" {
+		t.Errorf("First应为e，实际为%s", first)
+	}
+	if err := pq.UpdatePriority(handles["a"], 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := pq.UpdatePriority(handles["e"], -1); err != nil {
+		t.Fatal(err)
+	}
+	if handles["a"].Priority() != 10 || handles["a"].Value() != "a" {
+		t.Errorf("句柄a的值或优先级错误")
+	}
+	want := []string{"a", "d", "c", "b", "e"}
+	for _, name := range want {
+		if got := pq.Remove(); got != name {
+			t.Fatalf("移除的元素应为%s，实际为%s", name, got)
+		}
+	}
+	if err := pq.UpdatePriority(handles["a"], 1); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("已移除元素的句柄UpdatePriority应返回ErrInvalidHandle，实际为%v", err)
+	}
+	other := NewHeapPriorityQueueFunc[string](func(a, b float64) bool { return a > b })
+	h := other.Insert("x", 1)
+	if err := pq.UpdatePriority(h, 1); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("其他队列的句柄UpdatePriority应返回ErrInvalidHandle，实际为%v", err)
+	}
+}
+
+func TestHeapPriorityQueueRandom(t *testing.T) {
+	type entry struct{ priority, seq int }
+	pq := NewHeapPriorityQueue[int, int]()
+	var handles []*PQHandle[int, int]
+	n := 2000
+	for i := 0; i < n; i++ {
+		handles = append(handles, pq.Insert(i, rand.IntN(50)))
+	}
+	for i := 0; i < n; i += 3 {
+		pq.UpdatePriority(handles[i], rand.IntN(50))
+	}
+	prev := entry{-1, -1}
+	for !pq.IsEmpty() {
+		seq := pq.Remove()
+		cur := entry{handles[seq].Priority(), seq}
+		if cur.priority < prev.priority || (cur.priority == prev.priority && cur.seq < prev.seq) {
+			t.Fatalf("移除顺序错误：%v 在 %v 之后", cur, prev)
+		}
+		prev = cur
+	}
+}
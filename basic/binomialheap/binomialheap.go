@@ -0,0 +1,217 @@
+// binomialheap包实现了泛型的二项堆（binomial heap）。
+// !!! 二项堆是若干棵二项树（binomial tree）组成的森林：k阶二项树B(k)由两棵B(k-1)连接而成，
+// !!! 一棵作为另一棵树根的最左子树，因此B(k)有2^k个节点、树根有k个子节点。
+// !!! 二项堆中每种阶数的二项树最多只有一棵，就像n的二进制表示，n个元素的二项堆最多有log(n)+1棵树，
+// !!! 各棵树的树根按阶数从小到大组成“根链表”。
+// !!! 合并（Meld）两个二项堆就像两个二进制数相加：合并两条根链表后，把阶数相同的两棵树连接成高一阶的树（进位），
+// !!! 复杂度为O(log n)；插入就是与只有一个元素的二项堆合并；删除最小元素时，把最小树根的子树反转成根链表，再与其余的树合并。
+package binomialheap
+
+import "datastructure/basic/myheap"
+
+// Handle是二项堆中元素的句柄，Insert返回的句柄可以用于DecreaseKey。
+// !!! DecreaseKey通过与父节点交换元素来上浮，为了使句柄始终对应同一个元素，
+// !!! 句柄与树节点是分开的：交换的是节点所指向的句柄，同时更新句柄所指向的节点。
+type Handle[T any] struct {
+	value T
+	node  *node[T] // 元素所在的树节点，元素被移除后为nil
+}
+
+// Value返回句柄所对应的元素
+func (hd *Handle[T]) Value() T {
+	return hd.value
+}
+
+type node[T any] struct {
+	handle  *Handle[T]
+	parent  *node[T]
+	child   *node[T] // 阶数最高的子节点，子节点之间按阶数从大到小以sibling相连
+	sibling *node[T] // 对于树根，sibling是根链表中的下一棵树
+	degree  int      // 子节点的个数，即二项树的阶数
+}
+
+// Heap是泛型的二项堆，实现了myheap.Interface[T]接口。
+// Heap必须通过New创建，并且不能被拷贝。
+type Heap[T any] struct {
+	head *node[T] // 根链表，按阶数从小到大排列
+	size int
+	less func(a, b T) bool
+}
+
+var _ myheap.Interface[int] = (*Heap[int])(nil)
+
+// New创建一个空的二项堆，less(a, b)为true表示a应排在b的前面。
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// link把树根为y的二项树连接为树根为z的同阶二项树的最左子树
+func link[T any](y, z *node[T]) {
+	y.parent = z
+	y.sibling = z.child
+	z.child = y
+	z.degree++
+}
+
+// mergeRootLists把两条根链表按阶数从小到大归并为一条
+func mergeRootLists[T any](a, b *node[T]) *node[T] {
+	var head node[T]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return head.sibling
+}
+
+// union合并两条根链表，并把阶数相同的树两两连接，使每种阶数的树最多只有一棵。
+func (h *Heap[T]) union(a, b *node[T]) *node[T] {
+	head := mergeRootLists(a, b)
+	if head == nil {
+		return nil
+	}
+	var prev *node[T]
+	x := head
+	next := x.sibling
+	for next != nil {
+		if x.degree != next.degree || (next.sibling != nil && next.sibling.degree == x.degree) {
+			//!!! 阶数不同，或者有三棵同阶的树（先跳过第一棵，连接后两棵），继续向后处理
+			prev = x
+			x = next
+		} else if !h.less(next.handle.value, x.handle.value) {
+			x.sibling = next.sibling
+			link(next, x)
+		} else {
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			link(x, next)
+			x = next
+		}
+		next = x.sibling
+	}
+	return head
+}
+
+// minRoot返回根链表中元素最小的树根及其在根链表中的前一个树根
+func (h *Heap[T]) minRoot() (minNode, prevOfMin *node[T]) {
+	minNode = h.head
+	for prev, x := h.head, h.head.sibling; x != nil; prev, x = x, x.sibling {
+		if h.less(x.handle.value, minNode.handle.value) {
+			minNode, prevOfMin = x, prev
+		}
+	}
+	return minNode, prevOfMin
+}
+
+// Insert将元素x加入堆中，并返回元素的句柄，复杂度为O(log n)。
+func (h *Heap[T]) Insert(x T) *Handle[T] {
+	hd := &Handle[T]{value: x}
+	hd.node = &node[T]{handle: hd}
+	h.head = h.union(h.head, hd.node)
+	h.size++
+	return hd
+}
+
+// FindMin返回堆顶元素而不移除它，复杂度为O(log n)，堆为空时抛出panic。
+func (h *Heap[T]) FindMin() T {
+	if h.head == nil {
+		panic("堆已空，无法读取堆顶元素")
+	}
+	minNode, _ := h.minRoot()
+	return minNode.handle.value
+}
+
+// DeleteMin移除并返回堆顶元素，复杂度为O(log n)，堆为空时抛出panic。
+func (h *Heap[T]) DeleteMin() T {
+	if h.head == nil {
+		panic("堆已空，不能再弹出元素")
+	}
+	minNode, prev := h.minRoot()
+	if prev == nil {
+		h.head = minNode.sibling
+	} else {
+		prev.sibling = minNode.sibling
+	}
+	//!!! 子节点按阶数从大到小排列，反转后成为按阶数从小到大排列的根链表
+	var children *node[T]
+	for c := minNode.child; c != nil; {
+		next := c.sibling
+		c.parent = nil
+		c.sibling = children
+		children = c
+		c = next
+	}
+	h.head = h.union(h.head, children)
+	h.size--
+	minNode.handle.node = nil
+	return minNode.handle.value
+}
+
+// DecreaseKey将句柄hd中的元素替换为x，x不能排在原元素之后，复杂度为O(log n)。
+// 句柄对应的元素已被移除时返回myheap.ErrInvalidHandle，x排在原元素之后时返回myheap.ErrKeyIncreased。
+// !!! 句柄必须属于这个堆（或者已经被Meld合并到这个堆中的其他堆）。
+func (h *Heap[T]) DecreaseKey(hd *Handle[T], x T) error {
+	if hd == nil || hd.node == nil {
+		return myheap.ErrInvalidHandle
+	}
+	if h.less(hd.value, x) {
+		return myheap.ErrKeyIncreased
+	}
+	hd.value = x
+	//!!! 与myheap的up相同，只要比父节点小就与父节点交换（交换的是句柄）
+	n := hd.node
+	for n.parent != nil && h.less(n.handle.value, n.parent.handle.value) {
+		p := n.parent
+		n.handle, p.handle = p.handle, n.handle
+		n.handle.node = n
+		p.handle.node = p
+		n = p
+	}
+	return nil
+}
+
+// Meld将other中的所有元素合并到h中，复杂度为O(log n)，合并之后other成为空堆。
+// other中元素的句柄在合并之后仍然有效，可以用于h的DecreaseKey。
+func (h *Heap[T]) Meld(other *Heap[T]) {
+	if other == h {
+		return
+	}
+	h.head = h.union(h.head, other.head)
+	h.size += other.size
+	other.head = nil
+	other.size = 0
+}
+
+// Len返回堆中元素的个数
+func (h *Heap[T]) Len() int {
+	return h.size
+}
+
+// 以下方法实现了myheap.Interface[T]接口。
+
+// Push与Insert相同，但不返回句柄
+func (h *Heap[T]) Push(x T) {
+	h.Insert(x)
+}
+
+// Pop等价于DeleteMin
+func (h *Heap[T]) Pop() T {
+	return h.DeleteMin()
+}
+
+// Peek等价于FindMin
+func (h *Heap[T]) Peek() T {
+	return h.FindMin()
+}
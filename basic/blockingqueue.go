@@ -0,0 +1,225 @@
+package basic
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"time"
+)
+
+// BlockingQueue是容量固定、可以被多个goroutine并发访问的阻塞队列，实现了Queue[T]接口，存储结构为RingDeque。
+// !!! 队列已满时，Put（以及Insert）会阻塞生产者，直到有空闲位置；队列为空时，Take（以及Remove）会阻塞消费者，直到有元素可取。
+// !!! sync.Cond无法与context配合使用，因此这里用“通知通道”来唤醒等待者：等待者在释放锁之前取得当前的通知通道，
+// !!! 然后在通知通道和ctx.Done()上等待；状态变化时，关闭当前的通知通道（相当于Cond.Broadcast），并换上一个新的通知通道。
+// !!! 调用Close后，所有被阻塞的调用者都会被唤醒：Put立即返回ErrClosed，Take在取完剩余元素后返回ErrClosed。
+// BlockingQueue必须通过NewBlockingQueue创建，并且不能被拷贝。
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	items    RingDeque[T]
+	capacity int
+	closed   bool
+	policy   NilPolicy
+	notEmpty chan struct{} // 队列中有了元素（或队列被关闭）时被关闭，用于唤醒等待的消费者
+	notFull  chan struct{} // 队列中有了空闲位置（或队列被关闭）时被关闭，用于唤醒等待的生产者
+	takers   int           // 正在等待notEmpty的消费者个数
+	putters  int           // 正在等待notFull的生产者个数
+}
+
+// NewBlockingQueue创建一个容量为capacity的阻塞队列，capacity必须大于0。
+// 可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewBlockingQueue[T any](capacity int, opts ...Option) *BlockingQueue[T] {
+	if capacity <= 0 {
+		panic("阻塞队列的容量必须大于0")
+	}
+	c := newConfig(opts)
+	return &BlockingQueue[T]{
+		items:    NewRingDeque[T](WithCapacity(capacity), WithNilPolicy(AllowNil)),
+		capacity: capacity,
+		policy:   c.nilPolicy,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// broadcast关闭给定的通知通道以唤醒所有等待者，并换上一个新的通知通道。调用者必须持有锁。
+func broadcast(ch *chan struct{}) {
+	close(*ch)
+	*ch = make(chan struct{})
+}
+
+// wait释放锁并等待通知通道被关闭或ctx被取消，返回时重新持有锁。调用者必须持有锁。
+func (bq *BlockingQueue[T]) wait(ctx context.Context, ch chan struct{}, waiters *int) error {
+	*waiters++
+	bq.mu.Unlock()
+	var err error
+	select {
+	case <-ch:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	bq.mu.Lock()
+	*waiters--
+	return err
+}
+
+// Put将元素追加到队尾，队列已满时阻塞，直到有空闲位置、ctx被取消或队列被关闭。
+// 返回的错误为ErrClosed、ctx.Err()或被nil策略拒绝时的ErrNilValue、ErrZeroValue。
+func (bq *BlockingQueue[T]) Put(ctx context.Context, item T) error {
+	if err := checkValue(bq.policy, item); err != nil {
+		return err
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	for !bq.closed && bq.items.Size() >= bq.capacity {
+		if err := bq.wait(ctx, bq.notFull, &bq.putters); err != nil {
+			return err
+		}
+	}
+	if bq.closed {
+		return ErrClosed
+	}
+	bq.items.insertBack(item)
+	if bq.takers > 0 {
+		broadcast(&bq.notEmpty)
+	}
+	return nil
+}
+
+// Take移除并返回队头元素，队列为空时阻塞，直到有元素可取、ctx被取消或队列被关闭。
+// 队列被关闭后，Take仍然可以取出剩余的元素，队列取空后返回ErrClosed。
+func (bq *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	var zero T
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	for bq.items.IsEmpty() {
+		if bq.closed {
+			return zero, ErrClosed
+		}
+		if err := bq.wait(ctx, bq.notEmpty, &bq.takers); err != nil {
+			return zero, err
+		}
+	}
+	return bq.removeFirst(), nil
+}
+
+// removeFirst移除队头元素并唤醒等待的生产者。调用者必须持有锁，并保证队列不空。
+func (bq *BlockingQueue[T]) removeFirst() T {
+	item := bq.items.RemoveFirst()
+	if bq.putters > 0 {
+		broadcast(&bq.notFull)
+	}
+	return item
+}
+
+// Offer尝试将元素追加到队尾而不阻塞，队列已满、已关闭或元素被nil策略拒绝时返回false。
+func (bq *BlockingQueue[T]) Offer(item T) bool {
+	if checkValue(bq.policy, item) != nil {
+		return false
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.closed || bq.items.Size() >= bq.capacity {
+		return false
+	}
+	bq.items.insertBack(item)
+	if bq.takers > 0 {
+		broadcast(&bq.notEmpty)
+	}
+	return true
+}
+
+// Poll移除并返回队头元素，队列为空时最多等待timeout时间，超时或队列已关闭并取空时返回false。
+func (bq *BlockingQueue[T]) Poll(timeout time.Duration) (T, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	item, err := bq.Take(ctx)
+	return item, err == nil
+}
+
+// Close关闭队列，并唤醒所有被阻塞的生产者与消费者。重复调用Close没有任何效果。
+func (bq *BlockingQueue[T]) Close() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.closed {
+		return
+	}
+	bq.closed = true
+	broadcast(&bq.notEmpty)
+	broadcast(&bq.notFull)
+}
+
+// IsClosed判断队列是否已被关闭
+func (bq *BlockingQueue[T]) IsClosed() bool {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.closed
+}
+
+// Capacity返回队列的容量
+func (bq *BlockingQueue[T]) Capacity() int {
+	return bq.capacity
+}
+
+// 以下方法实现了Queue[T]接口。
+
+// Insert与Put相同，但不能被取消。队列已关闭或元素被nil策略拒绝时抛出panic。
+func (bq *BlockingQueue[T]) Insert(item T) {
+	if err := bq.Put(context.Background(), item); err != nil {
+		panic(err)
+	}
+}
+
+// Remove与Take相同，但不能被取消。队列已关闭并且已空时抛出panic。
+func (bq *BlockingQueue[T]) Remove() T {
+	item, err := bq.Take(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	return item
+}
+
+// First读取队头元素而不阻塞，队列为空时抛出panic。
+func (bq *BlockingQueue[T]) First() T {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.items.First()
+}
+
+// 读取队列元素的个数
+func (bq *BlockingQueue[T]) Size() int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.items.Size()
+}
+
+// 判断队列是否为空
+func (bq *BlockingQueue[T]) IsEmpty() bool {
+	return bq.Size() == 0
+}
+
+// Iterator以队列当前状态的快照创建一个迭代器，之后对队列的修改不会影响迭代器。
+func (bq *BlockingQueue[T]) Iterator() Iterator[T] {
+	return &queueIterator[T]{items: bq.snapshot()}
+}
+
+// All返回从队头到队尾遍历队列当前状态快照的迭代器，遍历过程中不持有锁。
+func (bq *BlockingQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range bq.snapshot() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// snapshot返回队列中所有元素的拷贝
+func (bq *BlockingQueue[T]) snapshot() []T {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	items := make([]T, 0, bq.items.Size())
+	for item := range bq.items.All() {
+		items = append(items, item)
+	}
+	return items
+}
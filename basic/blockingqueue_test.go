@@ -0,0 +1,129 @@
+package basic
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	var myQueue Queue[int] = NewBlockingQueue[int](3)
+	myQueue.Insert(15)
+	myQueue.Insert(20)
+	myQueue.Insert(30)
+	if myQueue.Remove() != 15 || myQueue.First() != 20 || myQueue.Size() != 2 {
+		t.Errorf("阻塞队列应遵循先进先出的顺序")
+	}
+	bq := NewBlockingQueue[int](2)
+	if !bq.Offer(1) || !bq.Offer(2) {
+		t.Errorf("队列未满时Offer应返回true")
+	}
+	if bq.Offer(3) {
+		t.Errorf("队列已满时Offer应返回false")
+	}
+	if item, ok := bq.Poll(time.Millisecond); !ok || item != 1 {
+		t.Errorf("Poll应返回(1,true)，实际为(%d,%v)", item, ok)
+	}
+	bq.Poll(time.Millisecond)
+	start := time.Now()
+	if _, ok := bq.Poll(20 * time.Millisecond); ok {
+		t.Errorf("空队列Poll应在超时后返回false")
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Errorf("空队列Poll应至少等待timeout时间")
+	}
+}
+
+// !!! 多个生产者与多个消费者通过一个容量很小的阻塞队列传递元素，用于在 go test -race 下检查数据竞争
+func TestBlockingQueueProducerConsumer(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 1000
+	bq := NewBlockingQueue[int](8)
+	var producerWG, consumerWG sync.WaitGroup
+	sums := make([]int, consumers)
+	for p := 0; p < producers; p++ {
+		producerWG.Add(1)
+		go func() {
+			defer producerWG.Done()
+			for i := 1; i <= perProducer; i++ {
+				if err := bq.Put(context.Background(), i); err != nil {
+					t.Errorf("Put返回了错误%v", err)
+					return
+				}
+			}
+		}()
+	}
+	for c := 0; c < consumers; c++ {
+		consumerWG.Add(1)
+		go func(c int) {
+			defer consumerWG.Done()
+			for {
+				item, err := bq.Take(context.Background())
+				if errors.Is(err, ErrClosed) {
+					return
+				}
+				sums[c] += item
+			}
+		}(c)
+	}
+	producerWG.Wait()
+	bq.Close()
+	consumerWG.Wait()
+	total := 0
+	for _, sum := range sums {
+		total += sum
+	}
+	if want := producers * perProducer * (perProducer + 1) / 2; total != want {
+		t.Errorf("消费者取得的元素之和应为%d，实际为%d", want, total)
+	}
+}
+
+func TestBlockingQueueCloseWakesWaiters(t *testing.T) {
+	empty := NewBlockingQueue[int](1)
+	full := NewBlockingQueue[int](1)
+	full.Insert(1)
+	errs := make(chan error, 2)
+	go func() {
+		_, err := empty.Take(context.Background())
+		errs <- err
+	}()
+	go func() {
+		errs <- full.Put(context.Background(), 2)
+	}()
+	time.Sleep(10 * time.Millisecond) //!!! 让两个goroutine进入阻塞状态
+	empty.Close()
+	full.Close()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, ErrClosed) {
+				t.Errorf("被Close唤醒的调用者应返回ErrClosed，实际为%v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Close没有唤醒被阻塞的调用者")
+		}
+	}
+	//!!! 关闭后仍可取出剩余元素，取空后返回ErrClosed
+	if item, err := full.Take(context.Background()); err != nil || item != 1 {
+		t.Errorf("关闭后Take应返回剩余元素(1,nil)，实际为(%d,%v)", item, err)
+	}
+	if _, err := full.Take(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("关闭并取空后Take应返回ErrClosed，实际为%v", err)
+	}
+	if full.Offer(3) {
+		t.Errorf("关闭后Offer应返回false")
+	}
+}
+
+func TestBlockingQueueContextCancel(t *testing.T) {
+	bq := NewBlockingQueue[*int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := bq.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ctx超时后Take应返回context.DeadlineExceeded，实际为%v", err)
+	}
+	if err := bq.Put(context.Background(), nil); !errors.Is(err, ErrNilValue) {
+		t.Errorf("Put nil值应返回ErrNilValue，实际为%v", err)
+	}
+}
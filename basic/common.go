@@ -0,0 +1,39 @@
+package basic
+
+import "reflect"
+
+type Node[T any] struct {
+	value T
+	next  *Node[T]
+}
+
+type DNode[T any] struct {
+	value T
+	pre   *DNode[T]
+	next  *DNode[T]
+}
+
+// !!! IsNil泛型函数判断给定的任何类型（any类型）值是否为nil。
+// !!! golang中，所有类型都是语言所提供的基础类型做源类型或组合所衍生的，
+// !!! 这些基本类型决定了被衍生类型的内存布局,也就决定其“零值”应该是nil还是0。
+// !!! 因而，只要判断给定类型的值是否属于以下种类（kind），
+// !!! 就可以通过该值调用IsZero（是否零值）判定其值是nil还是非nil。
+// !!! 所有接口类型，包括interface{}，也就是any类型在内的接口零值,——nil最为特殊，用该值调用IsZero会抛出异常，但是该nil值的
+// !!! kind是Invalid，因此可以用于判断是否为nil。（需要确定是否还有其他情况出现Invalid Kind的特殊值,但目前尚未发现）
+func IsNil[T any](t T) bool {
+	value := reflect.ValueOf(t)
+	kind := value.Kind()
+	switch kind {
+	case reflect.Invalid:
+		return true
+	case reflect.Interface, reflect.Pointer, reflect.Chan,
+		reflect.Func, reflect.UnsafePointer, reflect.Map, reflect.Slice:
+		if value.IsZero() {
+			return true
+		} else {
+			return false
+		}
+	default:
+		return false
+	}
+}
@@ -0,0 +1,266 @@
+package basic
+
+import (
+	"iter"
+	"slices"
+)
+
+// Deque泛型接口是双端队列的共同操作，可以在队头和队尾两端插入、移除与读取元素，
+// 也可以按序号（从队头开始计数，队头的序号为0）读写元素。
+// !!! 同一个算法可以运行在不同存储结构的双端队列上：SliceDeque、RingDeque与LinkedDeque都实现了这个接口。
+type Deque[T any] interface {
+	InsertFront(item T)
+	InsertBack(item T)
+	RemoveFirst() T
+	RemoveLast() T
+	First() T
+	Last() T
+	IsEmpty() bool
+	Size() int
+
+	At(i int) T              //读取序号为i的元素，序号超出[0,Size())时抛出panic
+	Set(i int, item T)       //替换序号为i的元素，序号超出[0,Size())时抛出panic
+	Rotate(k int)            //向队尾方向循环移动k步：k>0时队尾的k个元素移到队头，k<0时队头的-k个元素移到队尾
+	Clear()                  //移除所有元素
+	PushFrontAll(items ...T) //把items按原有顺序插入到队头，即插入后队头的元素依次为items[0]、items[1]……
+	PushBackAll(items ...T)  //把items按原有顺序追加到队尾
+	Reverse()                //把元素的顺序反转
+	Clone() Deque[T]         //返回一个包含相同元素、相同检查策略的独立的副本
+}
+
+// checkIndex在序号i超出[0,size)时抛出panic
+func checkIndex(i, size int) {
+	if i < 0 || i >= size {
+		panic("序号超出范围")
+	}
+}
+
+// normalizeRotation把任意的循环移动步数k化为[0,size)内等价的向队尾方向的步数
+func normalizeRotation(k, size int) int {
+	if size == 0 {
+		return 0
+	}
+	return (k%size + size) % size
+}
+
+// SliceDeque是以切片为存储结构的双端队列。
+type SliceDeque[T any] struct {
+	items  []T
+	policy NilPolicy
+}
+
+// NewSliceDeque创建一个空的SliceDeque，可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewSliceDeque[T any](opts ...Option) SliceDeque[T] {
+	c := newConfig(opts)
+	return SliceDeque[T]{policy: c.nilPolicy}
+}
+
+func (sdq *SliceDeque[T]) InsertFront(item T) {
+	if checkValue(sdq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	sdq.insertFront(item)
+}
+
+// insertFront不做任何检查，直接将元素插入到队头
+func (sdq *SliceDeque[T]) insertFront(item T) {
+	sdq.items = append(sdq.items, item)
+	l := len(sdq.items)
+	for i := l - 1; i > 0; i-- {
+		sdq.items[i] = sdq.items[i-1]
+	}
+	sdq.items[0] = item
+}
+func (sdq *SliceDeque[T]) InsertBack(item T) {
+	if checkValue(sdq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	sdq.items = append(sdq.items, item)
+}
+func (sdq *SliceDeque[T]) RemoveFirst() T {
+	if len(sdq.items) == 0 {
+		panic("队列已空，不能再删除元素")
+	}
+	result := sdq.items[0]
+	sdq.items = sdq.items[1:]
+	return result
+
+}
+func (sdq *SliceDeque[T]) RemoveLast() T {
+	l := len(sdq.items)
+	if l == 0 {
+		panic("队列已空，不能再删除元素")
+	}
+	result := sdq.items[l-1]
+	sdq.items = sdq.items[0 : l-1]
+	return result
+
+}
+func (sdq *SliceDeque[T]) First() T {
+	if len(sdq.items) == 0 {
+		panic("队列已空，无法读取第一个元素")
+	}
+	return sdq.items[0]
+}
+func (sdq *SliceDeque[T]) Last() T {
+	if len(sdq.items) == 0 {
+		panic("队列已空，无法读取最后一个元素")
+	}
+	l := len(sdq.items)
+	return sdq.items[l-1]
+}
+func (sdq *SliceDeque[T]) IsEmpty() bool {
+	return len(sdq.items) == 0
+
+}
+func (sdq *SliceDeque[T]) Size() int {
+	return len(sdq.items)
+}
+
+// InsertFrontErr与InsertFront相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (sdq *SliceDeque[T]) InsertFrontErr(item T) error {
+	if err := checkValue(sdq.policy, item); err != nil {
+		return err
+	}
+	sdq.insertFront(item)
+	return nil
+}
+
+// InsertBackErr与InsertBack相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (sdq *SliceDeque[T]) InsertBackErr(item T) error {
+	if err := checkValue(sdq.policy, item); err != nil {
+		return err
+	}
+	sdq.items = append(sdq.items, item)
+	return nil
+}
+
+// RemoveFirstErr与RemoveFirst相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (sdq *SliceDeque[T]) RemoveFirstErr() (T, error) {
+	var zero T
+	if len(sdq.items) == 0 {
+		return zero, ErrEmpty
+	}
+	return sdq.RemoveFirst(), nil
+}
+
+// TryRemoveFirst移除队头元素，队列为空时返回false。
+func (sdq *SliceDeque[T]) TryRemoveFirst() (T, bool) {
+	item, err := sdq.RemoveFirstErr()
+	return item, err == nil
+}
+
+// RemoveLastErr与RemoveLast相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (sdq *SliceDeque[T]) RemoveLastErr() (T, error) {
+	var zero T
+	if len(sdq.items) == 0 {
+		return zero, ErrEmpty
+	}
+	return sdq.RemoveLast(), nil
+}
+
+// TryRemoveLast移除队尾元素，队列为空时返回false。
+func (sdq *SliceDeque[T]) TryRemoveLast() (T, bool) {
+	item, err := sdq.RemoveLastErr()
+	return item, err == nil
+}
+
+// FirstErr与First相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (sdq *SliceDeque[T]) FirstErr() (T, error) {
+	var zero T
+	if len(sdq.items) == 0 {
+		return zero, ErrEmpty
+	}
+	return sdq.items[0], nil
+}
+
+// TryFirst读取队头元素，队列为空时返回false。
+func (sdq *SliceDeque[T]) TryFirst() (T, bool) {
+	item, err := sdq.FirstErr()
+	return item, err == nil
+}
+
+// LastErr与Last相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (sdq *SliceDeque[T]) LastErr() (T, error) {
+	var zero T
+	l := len(sdq.items)
+	if l == 0 {
+		return zero, ErrEmpty
+	}
+	return sdq.items[l-1], nil
+}
+
+// TryLast读取队尾元素，队列为空时返回false。
+func (sdq *SliceDeque[T]) TryLast() (T, bool) {
+	item, err := sdq.LastErr()
+	return item, err == nil
+}
+
+// All返回从队头到队尾遍历队列元素的迭代器
+func (sdq *SliceDeque[T]) All() iter.Seq[T] {
+	return forward(sdq.items)
+}
+
+// Backward返回从队尾到队头遍历队列元素的迭代器
+func (sdq *SliceDeque[T]) Backward() iter.Seq[T] {
+	return backward(sdq.items)
+}
+
+// At读取序号为i的元素
+func (sdq *SliceDeque[T]) At(i int) T {
+	checkIndex(i, len(sdq.items))
+	return sdq.items[i]
+}
+
+// Set替换序号为i的元素，新元素同样要经过检查策略的检查
+func (sdq *SliceDeque[T]) Set(i int, item T) {
+	checkIndex(i, len(sdq.items))
+	if checkValue(sdq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	sdq.items[i] = item
+}
+
+// Rotate向队尾方向循环移动k步。
+// !!! 切片的循环右移可以用三次反转完成：先反转整体，再分别反转前k个与其余的元素。
+func (sdq *SliceDeque[T]) Rotate(k int) {
+	k = normalizeRotation(k, len(sdq.items))
+	if k == 0 {
+		return
+	}
+	slices.Reverse(sdq.items)
+	slices.Reverse(sdq.items[:k])
+	slices.Reverse(sdq.items[k:])
+}
+
+// Clear移除所有元素，保留已分配的存储空间
+func (sdq *SliceDeque[T]) Clear() {
+	clear(sdq.items) //!!! 清除对已移除元素的引用，以便垃圾回收
+	sdq.items = sdq.items[:0]
+}
+
+// PushFrontAll把items按原有顺序插入到队头，有元素被检查策略拒绝时抛出panic，队列保持不变。
+func (sdq *SliceDeque[T]) PushFrontAll(items ...T) {
+	if checkValues(sdq.policy, items) != nil {
+		panic("空值不允许插入到队列")
+	}
+	sdq.items = slices.Insert(sdq.items, 0, items...)
+}
+
+// PushBackAll把items按原有顺序追加到队尾，有元素被检查策略拒绝时抛出panic，队列保持不变。
+func (sdq *SliceDeque[T]) PushBackAll(items ...T) {
+	if checkValues(sdq.policy, items) != nil {
+		panic("空值不允许插入到队列")
+	}
+	sdq.items = append(sdq.items, items...)
+}
+
+// Reverse把元素的顺序反转
+func (sdq *SliceDeque[T]) Reverse() {
+	slices.Reverse(sdq.items)
+}
+
+// Clone返回一个独立的副本
+func (sdq *SliceDeque[T]) Clone() Deque[T] {
+	return &SliceDeque[T]{items: slices.Clone(sdq.items), policy: sdq.policy}
+}
@@ -0,0 +1,5 @@
+/*
+Package basic提供了栈(Stack)、队列(Queue)、双端队列(Deque)、列表(List)和优先级队列(PriorityQueue)等
+基本数据结构的泛型实现，以及基于这些数据结构的一些算法示例（见各_test.go文件）。
+*/
+package basic
@@ -0,0 +1,26 @@
+package basic
+
+import "errors"
+
+// 以下是容器操作返回的哨兵错误（sentinel error），调用者可以用errors.Is来判断错误的种类。
+// !!! 容器的Pop、Remove、First等方法在容器为空时会抛出panic，而对应的PopErr、RemoveErr、FirstErr等方法
+// !!! 则以返回错误的方式报告同样的情况，TryPop、TryRemove、TryFirst等方法则仅以布尔值报告操作是否成功。
+var (
+	ErrEmpty      = errors.New("basic: 容器已空")    // 从空容器中读取或移除元素
+	ErrNilValue   = errors.New("basic: 不允许插入空值") // 向容器插入nil值
+	ErrZeroValue  = errors.New("basic: 不允许插入零值") // 在RejectZero策略下向容器插入类型的“零值”
+	ErrOutOfRange = errors.New("basic: 序号或优先级超出范围")
+	ErrClosed     = errors.New("basic: 队列已关闭") // 向已关闭的阻塞队列插入元素，或从已关闭并且已空的阻塞队列中取元素
+
+	ErrConcurrentModification = errors.New("basic: 迭代过程中容器被修改") // 迭代器创建之后容器被插入或删除了元素
+	ErrInvalidHandle          = errors.New("basic: 句柄无效")       // 句柄对应的元素已被移除，或者句柄不属于这个容器
+)
+
+// checkModCount比较容器当前的修改次数与迭代器创建时记录的修改次数，二者不同时抛出值为ErrConcurrentModification的panic。
+// !!! 与Java的ConcurrentModificationException一样，这是“快速失败（fail-fast）”机制：它只能尽力发现在迭代过程中
+// !!! 对容器的结构修改（通常是同一个goroutine在遍历时插入或删除了元素），并不能代替锁来保证并发安全。
+func checkModCount(modCount, expectedModCount int) {
+	if modCount != expectedModCount {
+		panic(ErrConcurrentModification)
+	}
+}
@@ -0,0 +1,105 @@
+package extsort
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"datastructure/basic/sorting"
+)
+
+// Codec负责记录的读写，并估计记录在内存中所占的字节数。
+// 输入、临时文件和输出使用同一种Codec，因此Write写出的记录必须能被Read原样读回。
+type Codec[T any] interface {
+	// Read从r中读取一条记录，没有更多记录时返回io.EOF，记录不完整时返回其他错误（例如io.ErrUnexpectedEOF）。
+	Read(r *bufio.Reader) (T, error)
+	// Write把一条记录写入w
+	Write(w *bufio.Writer, x T) error
+	// Size返回记录在内存中大约占用的字节数，用于控制每个有序片段的大小
+	Size(x T) int
+}
+
+// LineCodec以换行符分隔的文本行作为记录，例如日志文件。
+// !!! 读取时去掉行末的'\n'（但保留'\r'），写出时在每条记录之后加上'\n'；最后一行没有换行符时也作为一条记录。
+type LineCodec struct{}
+
+// stringHeaderSize是字符串头部（指针和长度）在64位平台上的大小
+const stringHeaderSize = 16
+
+func (LineCodec) Read(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err == io.EOF && len(line) > 0 {
+		return line, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+func (LineCodec) Write(w *bufio.Writer, x string) error {
+	if _, err := w.WriteString(x); err != nil {
+		return err
+	}
+	return w.WriteByte('\n')
+}
+
+func (LineCodec) Size(x string) int {
+	return len(x) + stringHeaderSize
+}
+
+// BinaryIntCodec以定长的二进制整数作为记录，每条记录占用与T相同的字节数（int和uint按其在当前平台上的位数）。
+// BinaryIntCodec必须通过NewBinaryIntCodec创建。
+type BinaryIntCodec[T sorting.Integer] struct {
+	order binary.ByteOrder
+	width int // 每条记录的字节数
+}
+
+// NewBinaryIntCodec创建按照字节序order读写T类型整数的Codec，例如binary.LittleEndian或binary.BigEndian。
+func NewBinaryIntCodec[T sorting.Integer](order binary.ByteOrder) BinaryIntCodec[T] {
+	var zero T
+	bits := 0
+	for x := ^zero; x != 0; x <<= 1 {
+		bits++
+	}
+	return BinaryIntCodec[T]{order: order, width: bits / 8}
+}
+
+func (c BinaryIntCodec[T]) Read(r *bufio.Reader) (T, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:c.width]); err != nil {
+		return 0, err //!!! 读到0个字节时是io.EOF，读到不足width个字节时是io.ErrUnexpectedEOF
+	}
+	switch c.width {
+	case 1:
+		return T(buf[0]), nil
+	case 2:
+		return T(c.order.Uint16(buf[:])), nil
+	case 4:
+		return T(c.order.Uint32(buf[:])), nil
+	default:
+		return T(c.order.Uint64(buf[:])), nil
+	}
+}
+
+func (c BinaryIntCodec[T]) Write(w *bufio.Writer, x T) error {
+	var buf [8]byte
+	//!!! 有符号整数转换为无符号整数时保留补码的低位，读回时再截断为T，因此负数也能原样读回
+	switch c.width {
+	case 1:
+		buf[0] = byte(x)
+	case 2:
+		c.order.PutUint16(buf[:], uint16(x))
+	case 4:
+		c.order.PutUint32(buf[:], uint32(x))
+	default:
+		c.order.PutUint64(buf[:], uint64(x))
+	}
+	_, err := w.Write(buf[:c.width])
+	return err
+}
+
+func (c BinaryIntCodec[T]) Size(T) int {
+	return c.width
+}
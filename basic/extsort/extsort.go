@@ -0,0 +1,262 @@
+// extsort包实现了外部排序（external sorting），用于排序无法一次装入内存的数据，例如数GB的日志文件。
+// !!! sorting包中的算法都假设数据可以放在一个切片中。外部归并排序分为两个阶段：
+// !!! 1. 生成片段：从输入中读取记录，直到所占的内存达到预算，用内存中的稳定排序排好后写入一个临时文件，
+// !!!    这样的有序片段（run）共有约N/M个（N是数据的大小，M是内存预算）；
+// !!! 2. 多路归并：用一个堆（myheap.Heap）同时归并k个片段，堆中每个片段只有当前的一条记录，
+// !!!    每次弹出最小的记录写入输出，再从它所在的片段读入下一条记录，复杂度为O(N log k)。
+// !!! 片段太多时同时打开的文件也太多，因此每次最多归并maxFanIn个片段，生成更长的片段，直到片段不超过maxFanIn个。
+// !!! 数据可以一次装入内存时不会创建临时文件。
+package extsort
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"datastructure/basic/myheap"
+	"datastructure/basic/sorting"
+)
+
+var ErrInvalidBudget = errors.New("extsort: 内存预算必须大于0")
+
+const (
+	defaultMaxFanIn = 64       // 默认每次最多归并的片段个数
+	ioBufferSize    = 64 << 10 // 读写每个临时文件所用的缓冲区大小
+)
+
+// config是Sort的可选参数的集合
+type config struct {
+	tempDir  string
+	maxFanIn int
+}
+
+// Option是Sort的可选参数
+type Option func(*config)
+
+// WithTempDir设置存放临时文件的目录，默认为os.TempDir()。
+func WithTempDir(dir string) Option {
+	return func(c *config) {
+		c.tempDir = dir
+	}
+}
+
+// WithMaxFanIn设置每次最多归并的片段个数，即同时打开用于读取的临时文件的个数（另有一个用于写入的临时文件），
+// k必须不小于2，默认为64。
+// !!! 所有的读缓冲区共占用k*64KB的内存，它们不计入内存预算。
+func WithMaxFanIn(k int) Option {
+	return func(c *config) {
+		c.maxFanIn = k
+	}
+}
+
+// Sort从r中读取codec格式的记录，按照cmp函数的顺序排序后以同样的格式写入w。
+// !!! memoryBudget是生成片段时所有记录在内存中所占的字节数的上限（按codec.Size估计），单个记录超过预算时自成一个片段。
+// !!! 排序是稳定的：片段内用稳定排序，归并时相等的记录中先生成的片段的排在前面，因此相等的记录保持输入中的相对顺序。
+// !!! 无论成功与否，返回之前都会删除所有的临时文件。
+func Sort[T any](r io.Reader, w io.Writer, codec Codec[T], cmp func(a, b T) int, memoryBudget int, opts ...Option) error {
+	if memoryBudget <= 0 {
+		return ErrInvalidBudget
+	}
+	c := config{maxFanIn: defaultMaxFanIn}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.maxFanIn < 2 {
+		panic("每次归并的片段个数不能小于2")
+	}
+	s := &sorter[T]{codec: codec, cmp: cmp, config: c}
+	defer s.cleanup()
+
+	bw := bufio.NewWriterSize(w, ioBufferSize)
+	runs, err := s.createRuns(bufio.NewReaderSize(r, ioBufferSize), bw, memoryBudget)
+	if err != nil {
+		return err
+	}
+	if len(runs) > 0 {
+		if err := s.mergeAll(runs, bw); err != nil {
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("extsort: 写入输出: %w", err)
+	}
+	return nil
+}
+
+// sorter保存一次外部排序的状态
+type sorter[T any] struct {
+	codec Codec[T]
+	cmp   func(a, b T) int
+	config
+	temps []string // 创建过的所有临时文件的名字，由cleanup删除
+}
+
+// createRuns读取全部输入，把每一段不超过内存预算的记录排好序后写入一个临时文件，返回这些临时文件的名字。
+// !!! 如果全部记录都在预算之内，就直接把排好序的记录写入out，不创建临时文件，返回空的片段列表。
+func (s *sorter[T]) createRuns(in *bufio.Reader, out *bufio.Writer, budget int) ([]string, error) {
+	var runs []string
+	var buf []T
+	size := 0
+	for {
+		x, err := s.codec.Read(in)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("extsort: 读取输入: %w", err)
+		}
+		if xs := s.codec.Size(x); size+xs > budget && len(buf) > 0 {
+			run, err := s.writeRun(buf)
+			if err != nil {
+				return nil, err
+			}
+			runs = append(runs, run)
+			clear(buf) //!!! 清除对已写出记录的引用，以便垃圾回收，同时复用buf的存储空间
+			buf, size = buf[:0], 0
+		}
+		buf = append(buf, x)
+		size += s.codec.Size(x)
+	}
+	if len(runs) == 0 {
+		sorting.SortStableFunc(buf, s.cmp)
+		return nil, s.writeAll(out, buf)
+	}
+	if len(buf) > 0 {
+		run, err := s.writeRun(buf)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// writeRun把buf中的记录排好序后写入一个新的临时文件，返回它的名字
+func (s *sorter[T]) writeRun(buf []T) (string, error) {
+	sorting.SortStableFunc(buf, s.cmp)
+	return s.writeTemp(func(w *bufio.Writer) error { return s.writeAll(w, buf) })
+}
+
+// writeTemp创建一个新的临时文件，用write写入内容后关闭它，返回它的名字。
+// !!! 片段写完后立即关闭，直到归并时才重新打开，因此同时打开的文件个数与片段的个数无关。
+func (s *sorter[T]) writeTemp(write func(w *bufio.Writer) error) (string, error) {
+	f, err := os.CreateTemp(s.tempDir, "extsort-run-*")
+	if err != nil {
+		return "", fmt.Errorf("extsort: 创建临时文件: %w", err)
+	}
+	s.temps = append(s.temps, f.Name())
+	bw := bufio.NewWriterSize(f, ioBufferSize)
+	if err := write(bw); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := bw.Flush(); err != nil {
+		f.Close()
+		return "", fmt.Errorf("extsort: 写入临时文件: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("extsort: 写入临时文件: %w", err)
+	}
+	return f.Name(), nil
+}
+
+func (s *sorter[T]) writeAll(w *bufio.Writer, records []T) error {
+	for _, x := range records {
+		if err := s.codec.Write(w, x); err != nil {
+			return fmt.Errorf("extsort: 写入记录: %w", err)
+		}
+	}
+	return nil
+}
+
+// cleanup删除所有的临时文件，此时它们都已被关闭；已经在归并后删除的文件会被忽略
+func (s *sorter[T]) cleanup() {
+	for _, name := range s.temps {
+		os.Remove(name)
+	}
+	s.temps = nil
+}
+
+// mergeAll把所有的片段归并到out中：片段多于maxFanIn个时，先每maxFanIn个归并为一个更长的片段。
+// !!! 每一趟按顺序分组，新片段保持原片段的先后顺序，因此归并仍然是稳定的。
+func (s *sorter[T]) mergeAll(runs []string, out *bufio.Writer) error {
+	for len(runs) > s.maxFanIn {
+		var merged []string
+		for i := 0; i < len(runs); i += s.maxFanIn {
+			group := runs[i:min(i+s.maxFanIn, len(runs))]
+			if len(group) == 1 {
+				merged = append(merged, group[0])
+				continue
+			}
+			name, err := s.writeTemp(func(w *bufio.Writer) error { return s.merge(group, w) })
+			if err != nil {
+				return err
+			}
+			merged = append(merged, name)
+		}
+		runs = merged
+	}
+	return s.merge(runs, out)
+}
+
+// mergeItem是多路归并的堆中的元素：片段run当前的记录
+type mergeItem[T any] struct {
+	value T
+	run   int
+}
+
+// merge用堆对runs中的片段做多路归并，结果写入out。
+// !!! 片段的文件只在归并期间打开，返回之前全部关闭，因此同时打开的文件不超过maxFanIn个。
+// !!! 相等的记录按片段的顺序排列，这保证了稳定性。
+func (s *sorter[T]) merge(runs []string, out *bufio.Writer) error {
+	files := make([]*os.File, 0, len(runs))
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	readers := make([]*bufio.Reader, len(runs))
+	h := myheap.NewWithArity(4, func(a, b mergeItem[T]) bool { //!!! 4叉堆的层数更少，适合频繁的“替换堆顶”操作
+		if c := s.cmp(a.value, b.value); c != 0 {
+			return c < 0
+		}
+		return a.run < b.run
+	})
+	for i, name := range runs {
+		f, err := os.Open(name)
+		if err != nil {
+			return fmt.Errorf("extsort: 打开临时文件: %w", err)
+		}
+		files = append(files, f)
+		readers[i] = bufio.NewReaderSize(f, ioBufferSize)
+		x, err := s.codec.Read(readers[i])
+		if err != nil { //!!! 片段至少有一条记录，这里的io.EOF也是错误
+			return fmt.Errorf("extsort: 读取临时文件: %w", err)
+		}
+		h.Push(mergeItem[T]{x, i})
+	}
+	for h.Len() > 0 {
+		top := h.Peek()
+		if err := s.codec.Write(out, top.value); err != nil {
+			return fmt.Errorf("extsort: 写入记录: %w", err)
+		}
+		x, err := s.codec.Read(readers[top.run])
+		switch {
+		case err == io.EOF:
+			h.Pop()
+		case err != nil:
+			return fmt.Errorf("extsort: 读取临时文件: %w", err)
+		default:
+			h.Set(0, mergeItem[T]{x, top.run}) //!!! 用同一片段的下一条记录替换堆顶，只需一次下沉
+		}
+	}
+	//!!! 归并完成的片段不再需要，尽早删除以释放磁盘空间
+	for _, f := range files {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	files = files[:0]
+	return nil
+}
@@ -0,0 +1,191 @@
+package extsort
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// checkTempDirEmpty检查排序结束后临时文件都已被删除
+func checkTempDirEmpty(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("排序结束后仍有%d个临时文件", len(entries))
+	}
+}
+
+func TestSortLines(t *testing.T) {
+	lines := make([]string, 3000)
+	for i := range lines {
+		lines[i] = strconv.FormatInt(rand.Int64(), 36)
+	}
+	input := strings.Join(lines, "\n") //!!! 最后一行没有换行符
+	want := slices.Clone(lines)
+	slices.Sort(want)
+
+	for _, tc := range []struct {
+		budget, fanIn int
+	}{
+		{1 << 30, 2}, // 全部在内存中，不创建临时文件
+		{3000, 64},   // 约30个片段，一趟归并
+		{3000, 3},    // 多趟归并
+		{1, 16},      // 每条记录自成一个片段（内存预算小于任何一条记录）
+		{30000, 100}, // 几个片段
+	} {
+		dir := t.TempDir()
+		var out bytes.Buffer
+		err := Sort(strings.NewReader(input), &out, LineCodec{}, strings.Compare, tc.budget,
+			WithTempDir(dir), WithMaxFanIn(tc.fanIn))
+		if err != nil {
+			t.Fatalf("budget=%d, fanIn=%d: %v", tc.budget, tc.fanIn, err)
+		}
+		if got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"); !slices.Equal(got, want) {
+			t.Errorf("budget=%d, fanIn=%d: 结果不正确", tc.budget, tc.fanIn)
+		}
+		checkTempDirEmpty(t, dir)
+	}
+}
+
+// 按照行的第一个字符排序，第一个字符相同的行应保持输入中的顺序
+func TestSortIsStable(t *testing.T) {
+	lines := make([]string, 5000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%c%d", 'a'+rand.IntN(5), i)
+	}
+	byFirst := func(a, b string) int { return cmp.Compare(a[0], b[0]) }
+	want := slices.Clone(lines)
+	slices.SortStableFunc(want, byFirst)
+
+	var out bytes.Buffer
+	if err := Sort(strings.NewReader(strings.Join(lines, "\n")+"\n"), &out, LineCodec{}, byFirst, 2000,
+		WithTempDir(t.TempDir()), WithMaxFanIn(4)); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"); !slices.Equal(got, want) {
+		t.Errorf("外部排序不是稳定的")
+	}
+}
+
+func testBinaryInts[T int16 | int32 | int64 | uint8 | uint32](t *testing.T, order binary.ByteOrder, gen func() T) {
+	codec := NewBinaryIntCodec[T](order)
+	nums := make([]T, 20000)
+	var input bytes.Buffer
+	for i := range nums {
+		nums[i] = gen()
+		if err := binary.Write(&input, order, nums[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	slices.Sort(nums)
+
+	var out bytes.Buffer
+	if err := Sort(&input, &out, codec, cmp.Compare[T], 1000, WithTempDir(t.TempDir()), WithMaxFanIn(8)); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]T, len(nums))
+	if err := binary.Read(&out, order, got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, nums) || out.Len() != 0 {
+		t.Errorf("%T(%v): 结果不正确", *new(T), order)
+	}
+}
+
+func TestSortBinaryInts(t *testing.T) {
+	testBinaryInts(t, binary.LittleEndian, func() int64 { return rand.Int64() - rand.Int64() })
+	testBinaryInts(t, binary.BigEndian, func() int32 { return rand.Int32() - rand.Int32() })
+	testBinaryInts(t, binary.LittleEndian, func() int16 { return int16(rand.IntN(1<<16) - 1<<15) })
+	testBinaryInts(t, binary.BigEndian, func() uint32 { return rand.Uint32() })
+	testBinaryInts(t, binary.LittleEndian, func() uint8 { return uint8(rand.IntN(256)) })
+}
+
+func TestSortErrors(t *testing.T) {
+	var out bytes.Buffer
+	if err := Sort(strings.NewReader("b\na\n"), &out, LineCodec{}, strings.Compare, 0); !errors.Is(err, ErrInvalidBudget) {
+		t.Errorf("应返回ErrInvalidBudget，实际为%v", err)
+	}
+	//!!! 空输入的结果也是空的
+	if err := Sort(strings.NewReader(""), &out, LineCodec{}, strings.Compare, 100); err != nil || out.Len() != 0 {
+		t.Errorf("空输入: err=%v, 输出%q", err, out.String())
+	}
+	//!!! 不完整的定长记录
+	dir := t.TempDir()
+	err := Sort(bytes.NewReader(make([]byte, 4*100+3)), &out, NewBinaryIntCodec[int32](binary.LittleEndian),
+		cmp.Compare[int32], 40, WithTempDir(dir))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("应返回io.ErrUnexpectedEOF，实际为%v", err)
+	}
+	checkTempDirEmpty(t, dir)
+	//!!! 输入出错时返回该错误，临时文件也被删除
+	dir = t.TempDir()
+	boom := errors.New("boom")
+	r := io.MultiReader(strings.NewReader(strings.Repeat("line\n", 1000)), iotest.ErrReader(boom))
+	if err := Sort(r, &out, LineCodec{}, strings.Compare, 100, WithTempDir(dir)); !errors.Is(err, boom) {
+		t.Errorf("应返回输入的错误，实际为%v", err)
+	}
+	checkTempDirEmpty(t, dir)
+}
+
+// fdCountingCodec在每次读取记录时统计进程打开的、位于dir中的文件个数，记录最大值。
+// !!! 统计依赖Linux的/proc/self/fd，每个打开的文件描述符都是指向文件路径的符号链接。
+type fdCountingCodec struct {
+	LineCodec
+	dir     string
+	maxOpen int
+}
+
+func (c *fdCountingCodec) Read(r *bufio.Reader) (string, error) {
+	entries, _ := os.ReadDir("/proc/self/fd")
+	open := 0
+	for _, e := range entries {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", e.Name())); err == nil && filepath.Dir(target) == c.dir {
+			open++
+		}
+	}
+	c.maxOpen = max(c.maxOpen, open)
+	return c.LineCodec.Read(r)
+}
+
+func TestSortOpenFilesBoundedByFanIn(t *testing.T) {
+	if _, err := os.Stat("/proc/self/fd"); err != nil {
+		t.Skip("需要/proc/self/fd来统计打开的文件")
+	}
+	lines := make([]string, 2000)
+	for i := range lines {
+		lines[i] = strconv.Itoa(rand.IntN(1000000))
+	}
+	want := slices.Clone(lines)
+	slices.Sort(want)
+	dir := t.TempDir()
+	codec := &fdCountingCodec{dir: dir}
+	var out bytes.Buffer
+	//!!! 每条记录自成一个片段，共2000个片段，远多于fanIn
+	if err := Sort(strings.NewReader(strings.Join(lines, "\n")), &out, codec, strings.Compare, 1,
+		WithTempDir(dir), WithMaxFanIn(4)); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"); !slices.Equal(got, want) {
+		t.Errorf("结果不正确")
+	}
+	//!!! 归并时最多打开4个片段用于读取，另有1个临时文件用于写入
+	if codec.maxOpen > 5 {
+		t.Errorf("同时打开了%d个临时文件，超过了fanIn+1=5", codec.maxOpen)
+	}
+	checkTempDirEmpty(t, dir)
+}
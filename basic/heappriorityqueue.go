@@ -0,0 +1,179 @@
+package basic
+
+import (
+	"cmp"
+
+	"datastructure/basic/myheap"
+)
+
+// HeapPriorityQueue是以二叉堆（binary heap）为存储结构的优先级队列，堆的维护由myheap包的Init、Push、Pop、Fix完成。
+// !!! PriorityQueue只能使用1到numberPriorities的整数优先级，每次Remove都要从最高优先级开始逐级查找非空的队列；
+// !!! HeapPriorityQueue的优先级P可以是任意类型，只要给出比较优先级的less函数（P为cmp.Ordered时默认使用“<”），
+// !!! less(a, b)为true表示优先级a高于优先级b，插入与移除的复杂度均为O(log n)。
+// !!! 堆本身不是稳定的，为了使同一优先级的元素按照先进先出的顺序排队，每个元素都记录了插入时的序号，
+// !!! 优先级相同时序号小（先插入）的元素排在前面。
+// !!! Insert返回元素的句柄（PQHandle），可以用UpdatePriority通过句柄修改元素的优先级，复杂度为O(log n)。
+// HeapPriorityQueue必须通过NewHeapPriorityQueue或NewHeapPriorityQueueFunc创建，并且不能被拷贝。
+type HeapPriorityQueue[T, P any] struct {
+	heap   pqHeap[T, P]
+	seq    uint64 // 下一个插入元素的序号
+	policy NilPolicy
+}
+
+// PQHandle是HeapPriorityQueue中元素的句柄，元素被移除之后句柄失效。
+type PQHandle[T, P any] struct {
+	value    T
+	priority P
+	seq      uint64                   // 插入序号，用于保证同一优先级的元素先进先出
+	index    int                      // 元素在堆中的位置，元素被移除后为-1
+	owner    *HeapPriorityQueue[T, P] // 元素所属的优先级队列
+}
+
+// Value返回句柄所对应的元素
+func (h *PQHandle[T, P]) Value() T {
+	return h.value
+}
+
+// Priority返回句柄所对应元素的优先级
+func (h *PQHandle[T, P]) Priority() P {
+	return h.priority
+}
+
+// pqHeap实现了myheap.MHeap接口，Swap时同步更新元素在堆中的位置。
+type pqHeap[T, P any] struct {
+	items []*PQHandle[T, P]
+	less  func(a, b P) bool
+}
+
+func (h *pqHeap[T, P]) Len() int {
+	return len(h.items)
+}
+func (h *pqHeap[T, P]) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if h.less(a.priority, b.priority) {
+		return true
+	}
+	if h.less(b.priority, a.priority) {
+		return false
+	}
+	return a.seq < b.seq //!!! 优先级相同时，先插入的元素排在前面
+}
+func (h *pqHeap[T, P]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+func (h *pqHeap[T, P]) Push(x any) {
+	handle := x.(*PQHandle[T, P])
+	handle.index = len(h.items)
+	h.items = append(h.items, handle)
+}
+func (h *pqHeap[T, P]) Pop() any {
+	n := len(h.items) - 1
+	handle := h.items[n]
+	h.items[n] = nil //!!! 清除对已移除元素的引用，以便垃圾回收
+	h.items = h.items[:n]
+	handle.index = -1
+	return handle
+}
+
+// NewHeapPriorityQueue创建一个空的HeapPriorityQueue，优先级的值越小优先级越高。
+// 可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewHeapPriorityQueue[T any, P cmp.Ordered](opts ...Option) *HeapPriorityQueue[T, P] {
+	return NewHeapPriorityQueueFunc[T](cmp.Less[P], opts...)
+}
+
+// NewHeapPriorityQueueFunc创建一个空的HeapPriorityQueue，less(a, b)为true表示优先级a高于优先级b。
+// 可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewHeapPriorityQueueFunc[T, P any](less func(a, b P) bool, opts ...Option) *HeapPriorityQueue[T, P] {
+	c := newConfig(opts)
+	return &HeapPriorityQueue[T, P]{heap: pqHeap[T, P]{less: less}, policy: c.nilPolicy}
+}
+
+// Insert以给定的优先级插入元素，并返回元素的句柄。元素被nil策略拒绝时抛出panic。
+func (hpq *HeapPriorityQueue[T, P]) Insert(item T, priority P) *PQHandle[T, P] {
+	if checkValue(hpq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	return hpq.insert(item, priority)
+}
+
+// InsertErr与Insert相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (hpq *HeapPriorityQueue[T, P]) InsertErr(item T, priority P) (*PQHandle[T, P], error) {
+	if err := checkValue(hpq.policy, item); err != nil {
+		return nil, err
+	}
+	return hpq.insert(item, priority), nil
+}
+
+func (hpq *HeapPriorityQueue[T, P]) insert(item T, priority P) *PQHandle[T, P] {
+	handle := &PQHandle[T, P]{value: item, priority: priority, seq: hpq.seq, owner: hpq}
+	hpq.seq++
+	myheap.Push(&hpq.heap, handle)
+	return handle
+}
+
+// Remove移除并返回优先级最高的元素，队列为空时抛出panic
+func (hpq *HeapPriorityQueue[T, P]) Remove() T {
+	if hpq.heap.Len() == 0 {
+		panic("队列已空，不能再移除元素")
+	}
+	return myheap.Pop(&hpq.heap).(*PQHandle[T, P]).value
+}
+
+// First读取优先级最高的元素，队列为空时抛出panic
+func (hpq *HeapPriorityQueue[T, P]) First() T {
+	if hpq.heap.Len() == 0 {
+		panic("队列已空，无法获得头元素")
+	}
+	return hpq.heap.items[0].value
+}
+
+// UpdatePriority将句柄所对应元素的优先级修改为priority，元素在同一优先级中的先后顺序仍以最初插入的顺序为准。
+// 句柄对应的元素已被移除，或者句柄不属于这个队列时返回ErrInvalidHandle。
+func (hpq *HeapPriorityQueue[T, P]) UpdatePriority(handle *PQHandle[T, P], priority P) error {
+	if handle == nil || handle.owner != hpq || handle.index < 0 {
+		return ErrInvalidHandle
+	}
+	handle.priority = priority
+	myheap.Fix(&hpq.heap, handle.index)
+	return nil
+}
+
+func (hpq *HeapPriorityQueue[T, P]) Size() int {
+	return hpq.heap.Len()
+}
+
+func (hpq *HeapPriorityQueue[T, P]) IsEmpty() bool {
+	return hpq.heap.Len() == 0
+}
+
+// RemoveErr与Remove相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (hpq *HeapPriorityQueue[T, P]) RemoveErr() (T, error) {
+	var zero T
+	if hpq.heap.Len() == 0 {
+		return zero, ErrEmpty
+	}
+	return hpq.Remove(), nil
+}
+
+// TryRemove移除优先级最高的元素，队列为空时返回false。
+func (hpq *HeapPriorityQueue[T, P]) TryRemove() (T, bool) {
+	item, err := hpq.RemoveErr()
+	return item, err == nil
+}
+
+// FirstErr与First相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (hpq *HeapPriorityQueue[T, P]) FirstErr() (T, error) {
+	var zero T
+	if hpq.heap.Len() == 0 {
+		return zero, ErrEmpty
+	}
+	return hpq.heap.items[0].value, nil
+}
+
+// TryFirst读取优先级最高的元素，队列为空时返回false。
+func (hpq *HeapPriorityQueue[T, P]) TryFirst() (T, bool) {
+	item, err := hpq.FirstErr()
+	return item, err == nil
+}
@@ -0,0 +1,75 @@
+package basic
+
+import "iter"
+
+// !!! Go 1.23引入了“range-over-func”迭代器：iter.Seq[T]是一个形如func(yield func(T) bool)的函数，
+// !!! 可以直接用于for-range循环，例如 for item := range queue.All() {...}。
+// !!! 本包中的容器都提供了All方法（以及在有意义时提供的Backward方法）来返回iter.Seq[T]，
+// !!! 以下两个函数则在传统的Iterator[T]接口与iter.Seq[T]之间进行转换。
+
+// Seq将传统的Iterator[T]转换为iter.Seq[T]，返回的序列只能被遍历一次，因为迭代器本身是有状态的。
+func Seq[T any](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for it.HasNext() {
+			if !yield(it.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq将iter.Seq[T]转换为传统的Iterator[T]。
+// !!! 转换基于iter.Pull，如果在迭代器遍历完之前就放弃使用它，必须调用返回的stop函数来释放iter.Pull所占用的资源；
+// !!! 迭代器遍历完毕后，stop函数会被自动调用，重复调用stop函数是安全的。
+func FromSeq[T any](seq iter.Seq[T]) (it Iterator[T], stop func()) {
+	next, stop := iter.Pull(seq)
+	return &seqIterator[T]{next: next, stop: stop}, stop
+}
+
+type seqIterator[T any] struct {
+	next    func() (T, bool)
+	stop    func()
+	item    T    // 预先读取的下一个元素
+	hasItem bool // item是否有效
+	fetched bool // 是否已经预先读取了下一个元素
+}
+
+func (si *seqIterator[T]) HasNext() bool {
+	if !si.fetched {
+		si.item, si.hasItem = si.next()
+		si.fetched = true
+		if !si.hasItem {
+			si.stop()
+		}
+	}
+	return si.hasItem
+}
+func (si *seqIterator[T]) Next() T {
+	if !si.HasNext() {
+		panic("迭代器已经没有下一个元素了！")
+	}
+	si.fetched = false
+	return si.item
+}
+
+// forward返回从前向后遍历切片的迭代器，与slices.Values相同
+func forward[T any](items []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// backward返回从后向前遍历切片的迭代器
+func backward[T any](items []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(items[i]) {
+				return
+			}
+		}
+	}
+}
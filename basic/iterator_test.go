@@ -0,0 +1,253 @@
+package basic
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestContainersAll(t *testing.T) {
+	sliceStack := NewSliceStack[int]()
+	sliceStackAny := NewSliceStackAny[int]()
+	nodeStack := NewNodeStack[int]()
+	lockFreeStack := NewLockFreeStack[int]()
+	sliceQueue := NewSliceQueue[int]()
+	nodeQueue := NewNodeQueue[int]()
+	ringDeque := NewRingDeque[int]()
+	blockingQueue := NewBlockingQueue[int](10)
+	lockFreeQueue := NewLockFreeQueue[int]()
+	sliceDeque := NewSliceDeque[int]()
+	sll := NewSingleLinkedList[int]()
+	dll := NewDoubleLinkedList[int]()
+	linkedDeque := NewLinkedDeque[int]()
+	for i := 1; i <= 5; i++ {
+		sliceStack.Push(i)
+		sliceStackAny.Push(i)
+		nodeStack.Push(i)
+		lockFreeStack.Push(i)
+		sliceQueue.Insert(i)
+		nodeQueue.Insert(i)
+		ringDeque.InsertBack(i)
+		blockingQueue.Insert(i)
+		lockFreeQueue.Insert(i)
+		sliceDeque.InsertBack(i)
+		sll.Append(i)
+		dll.Append(i)
+		linkedDeque.InsertBack(i)
+	}
+	forward := []int{1, 2, 3, 4, 5}
+	reversed := []int{5, 4, 3, 2, 1}
+	cases := []struct {
+		name string
+		seq  iter.Seq[int]
+		want []int
+	}{
+		{"SliceStack.All", sliceStack.All(), reversed},
+		{"SliceStack.Backward", sliceStack.Backward(), forward},
+		{"SliceStackAny.All", sliceStackAny.All(), reversed},
+		{"SliceStackAny.Backward", sliceStackAny.Backward(), forward},
+		{"NodeStack.All", nodeStack.All(), reversed},
+		{"LockFreeStack.All", lockFreeStack.All(), reversed},
+		{"SliceQueue.All", sliceQueue.All(), forward},
+		{"SliceQueue.Backward", sliceQueue.Backward(), reversed},
+		{"NodeQueue.All", nodeQueue.All(), forward},
+		{"RingDeque.All", ringDeque.All(), forward},
+		{"RingDeque.Backward", ringDeque.Backward(), reversed},
+		{"BlockingQueue.All", blockingQueue.All(), forward},
+		{"LockFreeQueue.All", lockFreeQueue.All(), forward},
+		{"SliceDeque.All", sliceDeque.All(), forward},
+		{"SliceDeque.Backward", sliceDeque.Backward(), reversed},
+		{"SingleLinkedList.All", sll.All(), forward},
+		{"DoubleLinkedList.All", dll.All(), forward},
+		{"DoubleLinkedList.Backward", dll.Backward(), reversed},
+		{"LinkedDeque.All", linkedDeque.All(), forward},
+		{"LinkedDeque.Backward", linkedDeque.Backward(), reversed},
+	}
+	for _, c := range cases {
+		if got := slices.Collect(c.seq); !slices.Equal(got, c.want) {
+			t.Errorf("%s应为%v，实际为%v", c.name, c.want, got)
+		}
+		//!!! 提前结束遍历（break）时，迭代器不能再调用yield
+		for range c.seq {
+			break
+		}
+	}
+}
+
+func TestPriorityQueueAll(t *testing.T) {
+	airlineQueue := NewPriorityQueue[string](3)
+	airlineQueue.Insert("Erika", 3)
+	airlineQueue.Insert("Madison", 1)
+	airlineQueue.Insert("James", 2)
+	airlineQueue.Insert("Frederik", 1)
+	want := []string{"Madison", "Frederik", "James", "Erika"}
+	if got := slices.Collect(airlineQueue.All()); !slices.Equal(got, want) {
+		t.Errorf("PriorityQueue.All应为%v，实际为%v", want, got)
+	}
+}
+
+// !!! 删除头、尾节点后，正反两个方向的遍历都不应看到已被删除的节点
+func TestLinkedListAllAfterRemove(t *testing.T) {
+	dll := NewDoubleLinkedList[int]()
+	sll := NewSingleLinkedList[int]()
+	for i := 1; i <= 4; i++ {
+		dll.Append(i)
+		sll.Append(i)
+	}
+	dll.RemoveAt(3)
+	dll.RemoveAt(0)
+	dll.Insert(0, 0)
+	if got := slices.Collect(dll.All()); !slices.Equal(got, []int{0, 2, 3}) {
+		t.Errorf("DoubleLinkedList.All应为[0 2 3]，实际为%v", got)
+	}
+	if got := slices.Collect(dll.Backward()); !slices.Equal(got, []int{3, 2, 0}) {
+		t.Errorf("DoubleLinkedList.Backward应为[3 2 0]，实际为%v", got)
+	}
+	sll.RemoveAt(3)
+	sll.Append(5)
+	if got := slices.Collect(sll.All()); !slices.Equal(got, []int{1, 2, 3, 5}) {
+		t.Errorf("SingleLinkedList.All应为[1 2 3 5]，实际为%v", got)
+	}
+}
+
+func TestIteratorSeqAdapters(t *testing.T) {
+	queue := NewSliceQueue[int]()
+	for i := 1; i <= 3; i++ {
+		queue.Insert(i)
+	}
+	if got := slices.Collect(Seq(queue.Iterator())); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Seq(Iterator)应为[1 2 3]，实际为%v", got)
+	}
+	it, stop := FromSeq(queue.All())
+	defer stop()
+	var got []int
+	for it.HasNext() {
+		got = append(got, it.Next())
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("FromSeq(All)应为[1 2 3]，实际为%v", got)
+	}
+	//!!! 提前放弃迭代器时调用stop
+	it, stop = FromSeq(queue.All())
+	if !it.HasNext() || it.Next() != 1 {
+		t.Errorf("FromSeq的第一个元素应为1")
+	}
+	stop()
+}
+
+// expectConcurrentModification执行f，并断言f抛出了值为ErrConcurrentModification的panic
+func expectConcurrentModification(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, ErrConcurrentModification) {
+			t.Errorf("%s: 期望panic(ErrConcurrentModification)，实际为%v", name, r)
+		}
+	}()
+	f()
+}
+
+func TestFailFastIterators(t *testing.T) {
+	newSliceQueue := func() *SliceQueue[int] {
+		q := NewSliceQueue[int]()
+		for i := 1; i <= 3; i++ {
+			q.Insert(i)
+		}
+		return &q
+	}
+	newNodeQueue := func() *NodeQueue[int] {
+		q := NewNodeQueue[int]()
+		for i := 1; i <= 3; i++ {
+			q.Insert(i)
+		}
+		return &q
+	}
+	newSLL := func() *SingleLinkedList[int] {
+		l := NewSingleLinkedList[int]()
+		for i := 1; i <= 3; i++ {
+			l.Append(i)
+		}
+		return &l
+	}
+	newDLL := func() *DoubleLinkedList[int] {
+		l := NewDoubleLinkedList[int]()
+		for i := 1; i <= 3; i++ {
+			l.Append(i)
+		}
+		return &l
+	}
+
+	expectConcurrentModification(t, "SliceQueue.Iterator", func() {
+		q := newSliceQueue()
+		it := q.Iterator()
+		it.Next()
+		q.Insert(4)
+		it.Next()
+	})
+	expectConcurrentModification(t, "SliceQueue.All", func() {
+		q := newSliceQueue()
+		for range q.All() {
+			q.Remove()
+		}
+	})
+	expectConcurrentModification(t, "SliceQueue.Backward", func() {
+		q := newSliceQueue()
+		for range q.Backward() {
+			q.Insert(0)
+		}
+	})
+	expectConcurrentModification(t, "NodeQueue.Iterator", func() {
+		q := newNodeQueue()
+		it := q.Iterator()
+		q.Remove()
+		it.Next()
+	})
+	expectConcurrentModification(t, "NodeQueue.All", func() {
+		q := newNodeQueue()
+		for range q.All() {
+			q.Insert(0)
+		}
+	})
+	expectConcurrentModification(t, "SingleLinkedList.All", func() {
+		l := newSLL()
+		for range l.All() {
+			l.RemoveAt(0)
+		}
+	})
+	expectConcurrentModification(t, "DoubleLinkedList.All", func() {
+		l := newDLL()
+		for range l.All() {
+			l.Append(0)
+		}
+	})
+	expectConcurrentModification(t, "DoubleLinkedList.Backward", func() {
+		l := newDLL()
+		for range l.Backward() {
+			l.Insert(0, 0)
+		}
+	})
+
+	// 不修改容器的遍历、遍历结束后再修改容器，以及提前结束遍历后修改容器都不应该抛出panic
+	q := newSliceQueue()
+	it := q.Iterator()
+	for it.HasNext() {
+		it.Next()
+	}
+	q.Insert(4)
+	for item := range q.All() {
+		if item == 2 {
+			break
+		}
+	}
+	q.Remove()
+	nq := newNodeQueue()
+	if got := slices.Collect(Seq(nq.Iterator())); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("NodeQueue.Iterator = %v", got)
+	}
+	nq.Remove()
+	if got := slices.Collect(nq.All()); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("NodeQueue.All = %v", got)
+	}
+}
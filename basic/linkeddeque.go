@@ -0,0 +1,363 @@
+package basic
+
+import "iter"
+
+// LinkedDeque是以双向链表节点（DNode）为存储结构的双端队列，同时实现了Deque[T]与Queue[T]接口。
+// !!! 两端的插入与移除都是严格的O(1)（不像SliceDeque和RingDeque那样需要搬移元素或扩容），代价是每个元素
+// !!! 需要单独分配一个节点；按序号访问需要从较近的一端沿着链表移动，复杂度为O(min(i, Size()-i))。
+// LinkedDeque的“零值”是一个可用的空队列。
+type LinkedDeque[T any] struct {
+	head     *DNode[T]
+	tail     *DNode[T]
+	size     int
+	modCount int //结构修改（插入、删除节点）的次数，用于迭代器检测并发修改
+	policy   NilPolicy
+}
+
+// NewLinkedDeque创建一个空的LinkedDeque，可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewLinkedDeque[T any](opts ...Option) LinkedDeque[T] {
+	c := newConfig(opts)
+	return LinkedDeque[T]{policy: c.nilPolicy}
+}
+
+func (ldq *LinkedDeque[T]) InsertFront(item T) {
+	if checkValue(ldq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	ldq.insertFront(item)
+}
+
+// insertFront不做任何检查，直接将元素作为新节点插入到队头
+func (ldq *LinkedDeque[T]) insertFront(item T) {
+	nd := &DNode[T]{value: item, next: ldq.head}
+	if ldq.head == nil {
+		ldq.tail = nd
+	} else {
+		ldq.head.pre = nd
+	}
+	ldq.head = nd
+	ldq.size++
+	ldq.modCount++
+}
+
+func (ldq *LinkedDeque[T]) InsertBack(item T) {
+	if checkValue(ldq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	ldq.insertBack(item)
+}
+
+// insertBack不做任何检查，直接将元素作为新节点追加到队尾
+func (ldq *LinkedDeque[T]) insertBack(item T) {
+	nd := &DNode[T]{value: item, pre: ldq.tail}
+	if ldq.tail == nil {
+		ldq.head = nd
+	} else {
+		ldq.tail.next = nd
+	}
+	ldq.tail = nd
+	ldq.size++
+	ldq.modCount++
+}
+
+func (ldq *LinkedDeque[T]) RemoveFirst() T {
+	if ldq.size == 0 {
+		panic("队列已空，不能再删除元素")
+	}
+	nd := ldq.head
+	ldq.head = nd.next
+	if ldq.head == nil { //!!! 删除了唯一的节点，尾节点也要清空
+		ldq.tail = nil
+	} else {
+		ldq.head.pre = nil
+	}
+	nd.next = nil
+	ldq.size--
+	ldq.modCount++
+	return nd.value
+}
+
+func (ldq *LinkedDeque[T]) RemoveLast() T {
+	if ldq.size == 0 {
+		panic("队列已空，不能再删除元素")
+	}
+	nd := ldq.tail
+	ldq.tail = nd.pre
+	if ldq.tail == nil { //!!! 删除了唯一的节点，头节点也要清空
+		ldq.head = nil
+	} else {
+		ldq.tail.next = nil
+	}
+	nd.pre = nil
+	ldq.size--
+	ldq.modCount++
+	return nd.value
+}
+
+func (ldq *LinkedDeque[T]) First() T {
+	if ldq.size == 0 {
+		panic("队列已空，无法读取第一个元素")
+	}
+	return ldq.head.value
+}
+
+func (ldq *LinkedDeque[T]) Last() T {
+	if ldq.size == 0 {
+		panic("队列已空，无法读取最后一个元素")
+	}
+	return ldq.tail.value
+}
+
+func (ldq *LinkedDeque[T]) IsEmpty() bool {
+	return ldq.size == 0
+}
+
+func (ldq *LinkedDeque[T]) Size() int {
+	return ldq.size
+}
+
+// 以下方法使LinkedDeque同时实现了Queue[T]接口：在队尾插入，从队头移除。
+
+// Insert将元素追加到队尾，等价于InsertBack
+func (ldq *LinkedDeque[T]) Insert(item T) {
+	ldq.InsertBack(item)
+}
+
+// Remove移除队头元素，等价于RemoveFirst
+func (ldq *LinkedDeque[T]) Remove() T {
+	return ldq.RemoveFirst()
+}
+
+// 以队列当前的状态创建一个“快速失败”的迭代器，迭代器创建之后如果队列被插入或删除了元素，
+// 迭代器的Next方法会抛出值为ErrConcurrentModification的panic。
+func (ldq *LinkedDeque[T]) Iterator() Iterator[T] {
+	return &linkedDequeIterator[T]{nextNode: ldq.head, ldq: ldq, expectedModCount: ldq.modCount}
+}
+
+type linkedDequeIterator[T any] struct {
+	nextNode         *DNode[T]
+	ldq              *LinkedDeque[T]
+	expectedModCount int
+}
+
+func (it *linkedDequeIterator[T]) HasNext() bool {
+	return it.nextNode != nil
+}
+func (it *linkedDequeIterator[T]) Next() T {
+	checkModCount(it.ldq.modCount, it.expectedModCount)
+	if !it.HasNext() {
+		panic("迭代器已经没有下一个元素了！")
+	}
+	item := it.nextNode.value
+	it.nextNode = it.nextNode.next
+	return item
+}
+
+// InsertFrontErr与InsertFront相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (ldq *LinkedDeque[T]) InsertFrontErr(item T) error {
+	if err := checkValue(ldq.policy, item); err != nil {
+		return err
+	}
+	ldq.insertFront(item)
+	return nil
+}
+
+// InsertBackErr与InsertBack相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (ldq *LinkedDeque[T]) InsertBackErr(item T) error {
+	if err := checkValue(ldq.policy, item); err != nil {
+		return err
+	}
+	ldq.insertBack(item)
+	return nil
+}
+
+// InsertErr等价于InsertBackErr
+func (ldq *LinkedDeque[T]) InsertErr(item T) error {
+	return ldq.InsertBackErr(item)
+}
+
+// RemoveFirstErr与RemoveFirst相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (ldq *LinkedDeque[T]) RemoveFirstErr() (T, error) {
+	var zero T
+	if ldq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return ldq.RemoveFirst(), nil
+}
+
+// TryRemoveFirst移除队头元素，队列为空时返回false。
+func (ldq *LinkedDeque[T]) TryRemoveFirst() (T, bool) {
+	item, err := ldq.RemoveFirstErr()
+	return item, err == nil
+}
+
+// RemoveErr等价于RemoveFirstErr
+func (ldq *LinkedDeque[T]) RemoveErr() (T, error) {
+	return ldq.RemoveFirstErr()
+}
+
+// TryRemove等价于TryRemoveFirst
+func (ldq *LinkedDeque[T]) TryRemove() (T, bool) {
+	return ldq.TryRemoveFirst()
+}
+
+// RemoveLastErr与RemoveLast相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (ldq *LinkedDeque[T]) RemoveLastErr() (T, error) {
+	var zero T
+	if ldq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return ldq.RemoveLast(), nil
+}
+
+// TryRemoveLast移除队尾元素，队列为空时返回false。
+func (ldq *LinkedDeque[T]) TryRemoveLast() (T, bool) {
+	item, err := ldq.RemoveLastErr()
+	return item, err == nil
+}
+
+// FirstErr与First相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (ldq *LinkedDeque[T]) FirstErr() (T, error) {
+	var zero T
+	if ldq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return ldq.head.value, nil
+}
+
+// TryFirst读取队头元素，队列为空时返回false。
+func (ldq *LinkedDeque[T]) TryFirst() (T, bool) {
+	item, err := ldq.FirstErr()
+	return item, err == nil
+}
+
+// LastErr与Last相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (ldq *LinkedDeque[T]) LastErr() (T, error) {
+	var zero T
+	if ldq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return ldq.tail.value, nil
+}
+
+// TryLast读取队尾元素，队列为空时返回false。
+func (ldq *LinkedDeque[T]) TryLast() (T, bool) {
+	item, err := ldq.LastErr()
+	return item, err == nil
+}
+
+// node返回序号为i的节点，从较近的一端开始查找
+func (ldq *LinkedDeque[T]) node(i int) *DNode[T] {
+	checkIndex(i, ldq.size)
+	if i < ldq.size/2 {
+		nd := ldq.head
+		for range i {
+			nd = nd.next
+		}
+		return nd
+	}
+	nd := ldq.tail
+	for range ldq.size - 1 - i {
+		nd = nd.pre
+	}
+	return nd
+}
+
+// At读取序号为i的元素
+func (ldq *LinkedDeque[T]) At(i int) T {
+	return ldq.node(i).value
+}
+
+// Set替换序号为i的元素，新元素同样要经过检查策略的检查。替换元素不是结构修改，不影响正在进行的迭代。
+func (ldq *LinkedDeque[T]) Set(i int, item T) {
+	nd := ldq.node(i)
+	if checkValue(ldq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	nd.value = item
+}
+
+// Rotate向队尾方向循环移动k步，复杂度为O(min(k, Size()-k))。
+// !!! 先把链表首尾相连成环，沿较近的方向找到新的队头，再在新队头之前断开。
+func (ldq *LinkedDeque[T]) Rotate(k int) {
+	k = normalizeRotation(k, ldq.size)
+	if k == 0 {
+		return
+	}
+	newHead := ldq.node(ldq.size - k)
+	ldq.tail.next, ldq.head.pre = ldq.head, ldq.tail
+	ldq.head, ldq.tail = newHead, newHead.pre
+	ldq.head.pre, ldq.tail.next = nil, nil
+	ldq.modCount++
+}
+
+// Clear移除所有元素
+func (ldq *LinkedDeque[T]) Clear() {
+	ldq.head, ldq.tail = nil, nil
+	ldq.size = 0
+	ldq.modCount++
+}
+
+// PushFrontAll把items按原有顺序插入到队头，有元素被检查策略拒绝时抛出panic，队列保持不变。
+func (ldq *LinkedDeque[T]) PushFrontAll(items ...T) {
+	if checkValues(ldq.policy, items) != nil {
+		panic("空值不允许插入到队列")
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		ldq.insertFront(items[i])
+	}
+}
+
+// PushBackAll把items按原有顺序追加到队尾，有元素被检查策略拒绝时抛出panic，队列保持不变。
+func (ldq *LinkedDeque[T]) PushBackAll(items ...T) {
+	if checkValues(ldq.policy, items) != nil {
+		panic("空值不允许插入到队列")
+	}
+	for _, item := range items {
+		ldq.insertBack(item)
+	}
+}
+
+// Reverse把元素的顺序反转：交换每个节点的前后链接，再交换头尾节点，不需要分配新的节点。
+func (ldq *LinkedDeque[T]) Reverse() {
+	for nd := ldq.head; nd != nil; nd = nd.pre { //!!! 交换之后，原来的下一个节点是pre
+		nd.pre, nd.next = nd.next, nd.pre
+	}
+	ldq.head, ldq.tail = ldq.tail, ldq.head
+	ldq.modCount++
+}
+
+// Clone返回一个独立的副本，副本与原队列不共享任何节点
+func (ldq *LinkedDeque[T]) Clone() Deque[T] {
+	c := &LinkedDeque[T]{policy: ldq.policy}
+	for nd := ldq.head; nd != nil; nd = nd.next {
+		c.insertBack(nd.value)
+	}
+	return c
+}
+
+// All返回从队头到队尾遍历队列元素的迭代器，遍历过程中如果队列被修改，会抛出值为ErrConcurrentModification的panic。
+func (ldq *LinkedDeque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		expectedModCount := ldq.modCount
+		for nd := ldq.head; nd != nil; nd = nd.next {
+			if !yield(nd.value) {
+				return
+			}
+			checkModCount(ldq.modCount, expectedModCount)
+		}
+	}
+}
+
+// Backward返回从队尾到队头遍历队列元素的迭代器，与All一样会检测并发修改。
+func (ldq *LinkedDeque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		expectedModCount := ldq.modCount
+		for nd := ldq.tail; nd != nil; nd = nd.pre {
+			if !yield(nd.value) {
+				return
+			}
+			checkModCount(ldq.modCount, expectedModCount)
+		}
+	}
+}
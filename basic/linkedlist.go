@@ -0,0 +1,644 @@
+package basic
+
+import "iter"
+
+// List泛型接口提取了所有列表类型的共同性操作。
+type List[T any] interface {
+	First() T                        //Returns the first node in the list
+	Last() T                         //Returns the last node in the list
+	Size() int                       //Returns the number of nodes in the list
+	Insert(i int, item T)            //Creates and inserts item in the ith node of the list
+	Prepend(item T)                  //Creates and inserts item into the first node of the list
+	RemoveAt(i int) T                //Removes and returns the item in the ith node of the list
+	RemoveFunc(del func(T) bool) int //Removes all items for which del returns true, returns the number of removed items
+	Append(item T)                   //Creates and inserts item into the last node of the list
+	Get(i int) T                     // Returns the node position containing item in the list
+	Set(i int, item T)               //Replaces the item in the ith node of the list
+	Items() []T                      //Returns a slice of all the items in the list
+	Clear()                          //Removes all the nodes of the list
+	Reverse()                        //Reverses the order of the nodes in place
+	SortFunc(cmp func(a, b T) int)   //Sorts the nodes in place, the sort is stable
+	Sublist(from, to int) List[T]    //Returns a new list containing a copy of the items in [from, to)
+	All() iter.Seq[T]                //Returns an iterator over the items from the first to the last
+}
+
+// !!! 这个函数来自于go 1.21.0 开始发布的slices包。
+// !!! SingleLinkedList[T any] 给出了任何类型的列表容器的通用操作，无法给出针对可比较类型（comparable）元素
+// !!! 的查找（Find）操作，也无法给出可排序类型（ordered)元素的找到最大值(Max)、最小值(Min、排序(Sort)等操作.
+// !!! 故而根据 [T any]可能是comparable或ordered类型，给出相应的辅助数据与行为分离的函数式编程思想的运用，即，
+// !!! 根据数据类型的共性特征（由接口所代表的方法集）来给出独立的操作函数。这样可解决面向对象编程思想中的一些约束问题，
+// !!! 比如，强制要求所操作元素的类型必须拥有特定的特征，比如要求元素必须是可比较（comparable）的或可排序的（ordered）。
+// !!! Find与IndexOf相同。早先的实现在循环中调用l.Get(index)，而链表的Get需要从头移动到第index个节点，
+// !!! 整个查找是O(n²)的；现在通过All顺序遍历，只需O(n)。
+func Find[E comparable](l List[E], e E) int {
+	return IndexOf(l, e)
+}
+
+// IndexOf返回e在列表中第一次出现的序号，不存在时返回-1。
+func IndexOf[E comparable](l List[E], e E) int {
+	index := 0
+	for item := range l.All() {
+		if item == e {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// Contains判断e是否在列表中
+func Contains[E comparable](l List[E], e E) bool {
+	return IndexOf(l, e) >= 0
+}
+
+// checkSublistRange在[from, to)不是[0, size]的子区间时抛出panic
+func checkSublistRange(from, to, size int) {
+	if from < 0 || to > size || from > to {
+		panic("子列表的范围超出列表")
+	}
+}
+
+// ///////////////////////////////////以下是单向列表的操作
+
+// SingleLinkedList是以单向链表节点（Node）为存储结构的列表。
+type SingleLinkedList[T any] struct {
+	head     *Node[T]
+	tail     *Node[T] //方便Append操作，即，在尾部追加节点,通常的单向链表没有这个节点
+	size     int
+	modCount int //结构修改（插入、删除节点）的次数，用于迭代器检测并发修改
+}
+
+// NewSingleLinkedList创建一个空的单向链表。
+func NewSingleLinkedList[T any]() SingleLinkedList[T] {
+	return SingleLinkedList[T]{}
+}
+
+func (sll *SingleLinkedList[T]) Size() int {
+	return sll.size
+}
+func (sll *SingleLinkedList[T]) IsEmpty() bool {
+	return sll.size == 0
+}
+
+func (sll SingleLinkedList[T]) First() T {
+	if sll.size == 0 {
+		panic("列表为空，无法读取第一个元素")
+	}
+	return sll.head.value
+}
+
+// Last返回最后一个元素，借助尾节点只需O(1)
+func (sll SingleLinkedList[T]) Last() T {
+	if sll.size == 0 {
+		panic("列表为空，无法读取最后一个元素")
+	}
+	return sll.tail.value
+}
+func (sll SingleLinkedList[T]) Items() []T {
+	result := make([]T, 0, sll.size)
+	for node := sll.head; node != nil; node = node.next { //!!! 最后一个节点的next为nil，但它本身也是元素
+		result = append(result, node.value)
+	}
+	return result
+
+}
+func (sll *SingleLinkedList[T]) addNodeToEmpyList(nd *Node[T]) {
+	if sll.size > 0 {
+		panic("列表不空，操作错误")
+	}
+	sll.head = nd
+	sll.tail = nd
+	sll.size++
+	sll.modCount++
+}
+func (sll *SingleLinkedList[T]) Append(value T) {
+	nd := &Node[T]{
+		value: value,
+		next:  nil,
+	}
+	if sll.size == 0 {
+		sll.addNodeToEmpyList(nd)
+		return
+	}
+	sll.tail.next = nd
+	sll.tail = nd
+	sll.size++
+	sll.modCount++
+
+}
+func (sll *SingleLinkedList[T]) Insert(i int, value T) {
+	newNd := &Node[T]{value: value, next: nil}
+	if sll.size == 0 {
+		sll.addNodeToEmpyList(newNd)
+		return
+	}
+	position := i
+	if position < 0 {
+		position = 0
+	} else if position > sll.size {
+		position = sll.size
+	}
+	if position == 0 {
+		oldHead := sll.head
+		sll.head = newNd
+		newNd.next = oldHead
+		sll.size++
+		sll.modCount++
+		return
+	}
+	if position == sll.size {
+		sll.tail.next = newNd
+		sll.tail = newNd
+		sll.size++
+		sll.modCount++
+		return
+	}
+	preNode := sll.getNode(position - 1)
+	curNodeAtPosition := sll.getNode(position)
+	preNode.next = newNd
+	newNd.next = curNodeAtPosition
+	sll.size++
+	sll.modCount++
+}
+func (sll *SingleLinkedList[T]) RemoveAt(i int) T {
+	if sll.IsEmpty() {
+		panic("试图从空列表中删除元素")
+	}
+	var ndTobeDelete *Node[T]
+	if i == 0 {
+		ndTobeDelete = sll.head
+		sll.head = ndTobeDelete.next
+		if sll.head == nil { //!!! 删除了唯一的节点，尾节点也要清空
+			sll.tail = nil
+		}
+		sll.size--
+		sll.modCount++
+		return ndTobeDelete.value
+	}
+	preNode := sll.getNode(i - 1)
+	ndTobeDelete = sll.getNode(i)
+	preNode.next = ndTobeDelete.next
+	if ndTobeDelete == sll.tail { //!!! 删除了尾节点，其前一个节点成为新的尾节点
+		sll.tail = preNode
+	}
+	sll.size--
+	sll.modCount++
+	return ndTobeDelete.value
+}
+func (sll *SingleLinkedList[T]) getNode(i int) *Node[T] {
+	if i < 0 || i >= sll.size || sll.IsEmpty() {
+		panic("无法获取非法序号的节点")
+	}
+	index := 0
+	nd := sll.head
+	for {
+		if index == i {
+			return nd
+		} else {
+			nd = nd.next
+			index++
+		}
+	}
+}
+func (sll *SingleLinkedList[T]) Get(i int) T {
+	nd := sll.getNode(i)
+	return nd.value
+}
+
+// Set替换第i个元素，替换元素不是结构修改，不影响正在进行的迭代
+func (sll *SingleLinkedList[T]) Set(i int, item T) {
+	sll.getNode(i).value = item
+}
+
+// Prepend在列表头部插入元素，复杂度为O(1)
+func (sll *SingleLinkedList[T]) Prepend(item T) {
+	sll.Insert(0, item)
+}
+
+// RemoveFunc在一次遍历中删除所有使del返回true的元素，返回删除的个数
+func (sll *SingleLinkedList[T]) RemoveFunc(del func(T) bool) int {
+	removed := 0
+	var pre *Node[T]
+	for nd := sll.head; nd != nil; nd = nd.next {
+		if !del(nd.value) {
+			pre = nd
+			continue
+		}
+		if pre == nil {
+			sll.head = nd.next
+		} else {
+			pre.next = nd.next
+		}
+		removed++
+	}
+	if removed > 0 {
+		sll.tail = pre //!!! 最后一个保留下来的节点成为尾节点，全部删除时为nil
+		sll.size -= removed
+		sll.modCount++
+	}
+	return removed
+}
+
+// Clear删除所有节点
+func (sll *SingleLinkedList[T]) Clear() {
+	sll.head, sll.tail = nil, nil
+	sll.size = 0
+	sll.modCount++
+}
+
+// Reverse就地反转链表：逐个把节点的next指向它原来的前一个节点
+func (sll *SingleLinkedList[T]) Reverse() {
+	var pre *Node[T]
+	for nd := sll.head; nd != nil; {
+		next := nd.next
+		nd.next = pre
+		pre, nd = nd, next
+	}
+	sll.head, sll.tail = sll.tail, sll.head
+	sll.modCount++
+}
+
+// SortFunc对链表做稳定的归并排序，复杂度为O(n log n)。
+// !!! 与数组上的归并排序不同，合并两个有序链表只需改变节点的链接，不需要额外的O(n)空间，
+// !!! 也不会移动元素的值，因此排序之后原来指向节点的引用仍然指向同一个元素。
+func (sll *SingleLinkedList[T]) SortFunc(cmp func(a, b T) int) {
+	if sll.size < 2 {
+		return
+	}
+	sll.head = sortNodes(sll.head, sll.size, cmp)
+	nd := sll.head
+	for nd.next != nil {
+		nd = nd.next
+	}
+	sll.tail = nd
+	sll.modCount++
+}
+
+// sortNodes对从head开始的n个节点做归并排序，返回排序后的头节点，排序后最后一个节点的next为nil
+func sortNodes[T any](head *Node[T], n int, cmp func(a, b T) int) *Node[T] {
+	if n == 1 {
+		head.next = nil
+		return head
+	}
+	mid := head
+	for range n/2 - 1 {
+		mid = mid.next
+	}
+	right := mid.next
+	left := sortNodes(head, n/2, cmp) //!!! 排序左半部分会改变mid.next，所以要先取出右半部分的头节点
+	right = sortNodes(right, n-n/2, cmp)
+
+	var dummy Node[T]
+	tail := &dummy
+	for left != nil && right != nil {
+		if cmp(left.value, right.value) <= 0 { //!!! 相等时取左边的节点，保证排序是稳定的
+			tail.next, left = left, left.next
+		} else {
+			tail.next, right = right, right.next
+		}
+		tail = tail.next
+	}
+	if left != nil {
+		tail.next = left
+	} else {
+		tail.next = right
+	}
+	return dummy.next
+}
+
+// Sublist返回一个新的单向链表，包含第from到第to-1个元素的副本
+func (sll *SingleLinkedList[T]) Sublist(from, to int) List[T] {
+	checkSublistRange(from, to, sll.size)
+	sub := &SingleLinkedList[T]{}
+	if from == to {
+		return sub
+	}
+	nd := sll.getNode(from)
+	for range to - from {
+		sub.Append(nd.value)
+		nd = nd.next
+	}
+	return sub
+}
+
+// All返回从头到尾遍历列表元素的迭代器，遍历过程中如果列表被插入或删除了节点，
+// 迭代器会抛出值为ErrConcurrentModification的panic。
+func (sll *SingleLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		expectedModCount := sll.modCount
+		for nd := sll.head; nd != nil; nd = nd.next {
+			if !yield(nd.value) {
+				return
+			}
+			checkModCount(sll.modCount, expectedModCount)
+		}
+	}
+}
+
+// DoubleLinkedList是以双向链表节点（DNode）为存储结构的列表，可以从头、尾两个方向访问节点。
+type DoubleLinkedList[T any] struct {
+	head     *DNode[T]
+	tail     *DNode[T]
+	size     int
+	modCount int //结构修改（插入、删除节点）的次数，用于迭代器检测并发修改
+	policy   NilPolicy
+}
+
+// NewDoubleLinkedList创建一个空的双向链表，可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewDoubleLinkedList[T any](opts ...Option) DoubleLinkedList[T] {
+	c := newConfig(opts)
+	return DoubleLinkedList[T]{policy: c.nilPolicy}
+}
+
+func (dll DoubleLinkedList[T]) First() T { //Returns the first node in the list
+	if dll.size == 0 {
+		panic("列表为空，无法读取第一个元素")
+	}
+	return dll.head.value
+}
+
+func (dll DoubleLinkedList[T]) Last() T { //Returns the last node in the list
+	if dll.size == 0 {
+		panic("列表为空，无法读取最后一个元素")
+	}
+	return dll.tail.value
+}
+
+func (dll DoubleLinkedList[T]) Size() int { //Returns the number of nodes in the list
+	return dll.size
+}
+func (dll *DoubleLinkedList[T]) addNodeToEmpyList(nd *DNode[T]) {
+	if dll.size != 0 {
+		panic("列表不空，操作错误")
+	}
+	dll.head = nd
+	dll.tail = nd
+	dll.size += 1
+	dll.modCount++
+}
+func (dll *DoubleLinkedList[T]) doInsert(oldNode, nd *DNode[T]) {
+	if oldNode == nil {
+		panic("插入所在位置的节点不存在")
+	}
+	preNode := oldNode.pre
+	if preNode == nil { //!!! 在头节点之前插入，新节点成为头节点
+		dll.head = nd
+	} else {
+		preNode.next = nd
+	}
+	nd.pre = preNode
+	nd.next = oldNode
+	oldNode.pre = nd
+	dll.size += 1
+	dll.modCount++
+}
+func (dll *DoubleLinkedList[T]) Insert(i int, item T) { //Creates and inserts item in the ith node of the list
+	if checkValue(dll.policy, item) != nil {
+		panic("不允许向列表插入空对象！")
+	}
+
+	nd := &DNode[T]{value: item, pre: nil, next: nil}
+	if dll.size == 0 {
+		dll.addNodeToEmpyList(nd)
+		return
+	}
+	if i < 0 {
+		dll.doInsert(dll.head, nd)
+		return
+	}
+	if i >= dll.size {
+		dll.Append(item)
+		return
+	}
+	if i >= dll.size/2 {
+		oldNode := dll.tail
+		for index := dll.size - 1; index > i; index-- {
+			oldNode = oldNode.pre
+		}
+		dll.doInsert(oldNode, nd)
+	} else {
+		oldNode := dll.head
+		for index := 0; index < i; index++ {
+			oldNode = oldNode.next
+		}
+		dll.doInsert(oldNode, nd)
+	}
+}
+func (dll *DoubleLinkedList[T]) doRemoveNode(node *DNode[T]) T {
+	dll.size -= 1
+	dll.modCount++
+	preNode := node.pre
+	nextNode := node.next
+	//!!! 被删除的节点可能是头节点或尾节点（或者二者都是），前后两个方向的链接要分别处理
+	if preNode == nil {
+		dll.head = nextNode
+	} else {
+		preNode.next = nextNode
+	}
+	if nextNode == nil {
+		dll.tail = preNode
+	} else {
+		nextNode.pre = preNode
+	}
+	node.pre, node.next = nil, nil
+	return node.value
+}
+func (dll *DoubleLinkedList[T]) RemoveAt(i int) T { //Removes and returns the item in the ith node of the list
+	if i < 0 || i >= dll.size {
+		panic("给定的元素位置超界")
+	}
+	if i >= dll.size/2 {
+		node := dll.tail
+		for index := dll.size - 1; index > i; index-- {
+			node = node.pre
+		}
+		return dll.doRemoveNode(node)
+	} else {
+		node := dll.head
+		for index := 0; index < i; index++ {
+			node = node.next
+		}
+		return dll.doRemoveNode(node)
+	}
+}
+
+func (dll *DoubleLinkedList[T]) Append(item T) { //Creates and inserts item into the last node of the list
+	if checkValue(dll.policy, item) != nil {
+		panic("不允许向列表插入空对象！")
+	}
+	nd := &DNode[T]{value: item, pre: nil, next: nil}
+	if dll.size == 0 {
+		dll.addNodeToEmpyList(nd)
+		return
+	}
+	nd.pre = dll.tail
+	dll.tail.next = nd
+	dll.tail = nd
+	dll.size += 1
+	dll.modCount++
+}
+
+// 双向链表获取制定位置的元素可以根据位置是靠近头节点还是尾结点来进行一些优化
+func (dll DoubleLinkedList[T]) Get(i int) T { // Returns the node position containing item in the list
+	return dll.getNode(i).value
+}
+
+// getNode根据位置是靠近头节点还是尾节点，从较近的一端开始查找第i个节点
+func (dll DoubleLinkedList[T]) getNode(i int) *DNode[T] {
+	if i < 0 || i >= dll.size || dll.size == 0 {
+		panic("无法获取非法序号的节点")
+	}
+	if i <= dll.size/2 {
+		nd := dll.head
+		for range i {
+			nd = nd.next
+		}
+		return nd
+	}
+	nd := dll.tail
+	for range dll.size - 1 - i {
+		nd = nd.pre
+	}
+	return nd
+}
+func (dll DoubleLinkedList[T]) Items() []T { //Returns a slice of all the items in the list
+	result := make([]T, 0, dll.size)
+	for nd := dll.head; nd != nil; nd = nd.next { //!!! 最后一个节点的next为nil，但它本身也是元素
+		result = append(result, nd.value)
+	}
+	return result
+}
+
+// Set替换第i个元素，新元素同样要经过检查策略的检查。替换元素不是结构修改，不影响正在进行的迭代。
+func (dll *DoubleLinkedList[T]) Set(i int, item T) {
+	nd := dll.getNode(i)
+	if checkValue(dll.policy, item) != nil {
+		panic("不允许向列表插入空对象！")
+	}
+	nd.value = item
+}
+
+// Prepend在列表头部插入元素，复杂度为O(1)
+func (dll *DoubleLinkedList[T]) Prepend(item T) {
+	dll.Insert(0, item)
+}
+
+// RemoveFunc在一次遍历中删除所有使del返回true的元素，返回删除的个数
+func (dll *DoubleLinkedList[T]) RemoveFunc(del func(T) bool) int {
+	removed := 0
+	for nd := dll.head; nd != nil; {
+		next := nd.next //!!! doRemoveNode会清空nd的链接
+		if del(nd.value) {
+			dll.doRemoveNode(nd)
+			removed++
+		}
+		nd = next
+	}
+	return removed
+}
+
+// Clear删除所有节点
+func (dll *DoubleLinkedList[T]) Clear() {
+	dll.head, dll.tail = nil, nil
+	dll.size = 0
+	dll.modCount++
+}
+
+// Reverse就地反转链表：交换每个节点的前后链接，再交换头尾节点
+func (dll *DoubleLinkedList[T]) Reverse() {
+	for nd := dll.head; nd != nil; nd = nd.pre { //!!! 交换之后，原来的下一个节点是pre
+		nd.pre, nd.next = nd.next, nd.pre
+	}
+	dll.head, dll.tail = dll.tail, dll.head
+	dll.modCount++
+}
+
+// SortFunc对链表做稳定的归并排序，复杂度为O(n log n)。
+// !!! 排序时只使用next链接，把双向链表当作单向链表来排序，排序之后再沿next方向重建pre链接与尾节点。
+func (dll *DoubleLinkedList[T]) SortFunc(cmp func(a, b T) int) {
+	if dll.size < 2 {
+		return
+	}
+	dll.head = sortDNodes(dll.head, dll.size, cmp)
+	var pre *DNode[T]
+	for nd := dll.head; nd != nil; nd = nd.next {
+		nd.pre = pre
+		pre = nd
+	}
+	dll.tail = pre
+	dll.modCount++
+}
+
+// sortDNodes与sortNodes相同，只是作用在双向链表节点上，并且不维护pre链接
+func sortDNodes[T any](head *DNode[T], n int, cmp func(a, b T) int) *DNode[T] {
+	if n == 1 {
+		head.next = nil
+		return head
+	}
+	mid := head
+	for range n/2 - 1 {
+		mid = mid.next
+	}
+	right := mid.next
+	left := sortDNodes(head, n/2, cmp)
+	right = sortDNodes(right, n-n/2, cmp)
+
+	var dummy DNode[T]
+	tail := &dummy
+	for left != nil && right != nil {
+		if cmp(left.value, right.value) <= 0 {
+			tail.next, left = left, left.next
+		} else {
+			tail.next, right = right, right.next
+		}
+		tail = tail.next
+	}
+	if left != nil {
+		tail.next = left
+	} else {
+		tail.next = right
+	}
+	return dummy.next
+}
+
+// Sublist返回一个新的双向链表，包含第from到第to-1个元素的副本，检查策略与原列表相同
+func (dll *DoubleLinkedList[T]) Sublist(from, to int) List[T] {
+	checkSublistRange(from, to, dll.size)
+	sub := &DoubleLinkedList[T]{policy: dll.policy}
+	if from == to {
+		return sub
+	}
+	nd := dll.getNode(from)
+	for range to - from {
+		sub.Append(nd.value)
+		nd = nd.next
+	}
+	return sub
+}
+
+// All返回从头到尾遍历列表元素的迭代器，遍历过程中如果列表被插入或删除了节点，
+// 迭代器会抛出值为ErrConcurrentModification的panic。
+func (dll *DoubleLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		expectedModCount := dll.modCount
+		for nd := dll.head; nd != nil; nd = nd.next {
+			if !yield(nd.value) {
+				return
+			}
+			checkModCount(dll.modCount, expectedModCount)
+		}
+	}
+}
+
+// Backward返回从尾到头遍历列表元素的迭代器，与All一样会检测并发修改。
+func (dll *DoubleLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		expectedModCount := dll.modCount
+		for nd := dll.tail; nd != nil; nd = nd.pre {
+			if !yield(nd.value) {
+				return
+			}
+			checkModCount(dll.modCount, expectedModCount)
+		}
+	}
+}
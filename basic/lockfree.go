@@ -0,0 +1,297 @@
+package basic
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// lfNode是无锁（lock-free）容器所使用的单向链表节点，与Node[T]的区别在于next是原子指针，
+// 可以被多个goroutine以CAS（CompareAndSwap）的方式并发修改。
+type lfNode[T any] struct {
+	value T
+	next  atomic.Pointer[lfNode[T]]
+}
+
+// LockFreeQueue是Michael–Scott无锁队列，可以被多个生产者和多个消费者（MPMC）并发访问，实现了Queue[T]接口。
+// !!! 与NodeQueue一样，LockFreeQueue以单向链表节点为存储结构，head指向一个“哨兵（dummy）”节点，
+// !!! 队头元素是哨兵节点的下一个节点，tail指向最后一个节点（或者落后于最后一个节点一步）。
+// !!! 插入时先用CAS把新节点挂到最后一个节点的next上，再用CAS把tail推进到新节点；如果某个goroutine发现tail落后，
+// !!! 就帮助推进tail，因此任何goroutine都不会因为其他goroutine被挂起而阻塞。
+// !!! 移除时用CAS把head推进到下一个节点，下一个节点就成为新的哨兵节点。
+// !!! 由于Go有垃圾回收，节点不会被复用，所以不存在ABA问题。
+// LockFreeQueue必须通过NewLockFreeQueue创建。
+type LockFreeQueue[T any] struct {
+	head   atomic.Pointer[lfNode[T]]
+	tail   atomic.Pointer[lfNode[T]]
+	length atomic.Int64
+	policy NilPolicy
+}
+
+// NewLockFreeQueue创建一个空的无锁队列，可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewLockFreeQueue[T any](opts ...Option) *LockFreeQueue[T] {
+	c := newConfig(opts)
+	lfq := &LockFreeQueue[T]{policy: c.nilPolicy}
+	dummy := &lfNode[T]{}
+	lfq.head.Store(dummy)
+	lfq.tail.Store(dummy)
+	return lfq
+}
+
+// 队列插入元素只能在尾部追加
+func (lfq *LockFreeQueue[T]) Insert(item T) {
+	if checkValue(lfq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	lfq.enqueue(item)
+}
+
+// InsertErr与Insert相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (lfq *LockFreeQueue[T]) InsertErr(item T) error {
+	if err := checkValue(lfq.policy, item); err != nil {
+		return err
+	}
+	lfq.enqueue(item)
+	return nil
+}
+
+func (lfq *LockFreeQueue[T]) enqueue(item T) {
+	nd := &lfNode[T]{value: item}
+	for {
+		tail := lfq.tail.Load()
+		next := tail.next.Load()
+		if tail != lfq.tail.Load() { //!!! 读取next期间tail已经被其他goroutine推进，重新读取
+			continue
+		}
+		if next != nil { //!!! tail落后于最后一个节点，帮助推进tail后重试
+			lfq.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, nd) { //!!! 新节点挂到了最后一个节点之后，插入完成
+			lfq.tail.CompareAndSwap(tail, nd) //!!! 尝试推进tail，失败说明其他goroutine已经帮忙推进了
+			break
+		}
+	}
+	lfq.length.Add(1)
+}
+
+// TryRemove移除队头元素，队列为空时返回false。
+func (lfq *LockFreeQueue[T]) TryRemove() (T, bool) {
+	var zero T
+	for {
+		head := lfq.head.Load()
+		tail := lfq.tail.Load()
+		next := head.next.Load()
+		if head != lfq.head.Load() {
+			continue
+		}
+		if next == nil { //!!! 哨兵节点之后没有节点，队列为空
+			return zero, false
+		}
+		if head == tail { //!!! 队列不空但tail仍指向哨兵节点，帮助推进tail后重试
+			lfq.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		//!!! 必须在CAS之前读取元素值，CAS成功后next成为新的哨兵节点，
+		//!!! 其value不能再被清除，因为其他在CAS中失败的goroutine可能正在读取它
+		item := next.value
+		if lfq.head.CompareAndSwap(head, next) {
+			lfq.length.Add(-1)
+			return item, true
+		}
+	}
+}
+
+// 队列的移除元素要从头部移除，队列为空时抛出panic
+func (lfq *LockFreeQueue[T]) Remove() T {
+	item, ok := lfq.TryRemove()
+	if !ok {
+		panic("队列已空，不能再删除元素")
+	}
+	return item
+}
+
+// RemoveErr与Remove相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (lfq *LockFreeQueue[T]) RemoveErr() (T, error) {
+	item, ok := lfq.TryRemove()
+	if !ok {
+		return item, ErrEmpty
+	}
+	return item, nil
+}
+
+// TryFirst读取队头元素，队列为空时返回false。
+func (lfq *LockFreeQueue[T]) TryFirst() (T, bool) {
+	var zero T
+	next := lfq.head.Load().next.Load()
+	if next == nil {
+		return zero, false
+	}
+	return next.value, true
+}
+
+// 读取队列头部的元素，队列为空时抛出panic
+func (lfq *LockFreeQueue[T]) First() T {
+	item, ok := lfq.TryFirst()
+	if !ok {
+		panic("队列已空，无法读取第一个元素")
+	}
+	return item
+}
+
+// Size返回队列元素的个数，在并发修改时只是一个近似值
+func (lfq *LockFreeQueue[T]) Size() int {
+	return int(max(lfq.length.Load(), 0))
+}
+
+// 判断队列是否为空
+func (lfq *LockFreeQueue[T]) IsEmpty() bool {
+	return lfq.head.Load().next.Load() == nil
+}
+
+// Iterator以队列当前的状态创建一个迭代器，迭代过程中其他goroutine对队列的修改可能被迭代器看到，也可能看不到，
+// 但迭代器不会重复或乱序返回元素。
+func (lfq *LockFreeQueue[T]) Iterator() Iterator[T] {
+	return &lockFreeIterator[T]{lfq.head.Load().next.Load()}
+}
+
+// All返回从队头到队尾遍历队列元素的迭代器，与Iterator一样，遍历过程中可能看到也可能看不到其他goroutine的修改。
+func (lfq *LockFreeQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for nd := lfq.head.Load().next.Load(); nd != nil; nd = nd.next.Load() {
+			if !yield(nd.value) {
+				return
+			}
+		}
+	}
+}
+
+type lockFreeIterator[T any] struct {
+	nextNode *lfNode[T]
+}
+
+func (lfi *lockFreeIterator[T]) HasNext() bool {
+	return lfi.nextNode != nil
+}
+func (lfi *lockFreeIterator[T]) Next() T {
+	if !lfi.HasNext() {
+		panic("迭代器已经没有下一个元素了！")
+	}
+	result := lfi.nextNode.value
+	lfi.nextNode = lfi.nextNode.next.Load()
+	return result
+}
+
+// LockFreeStack是Treiber无锁栈，可以被多个goroutine并发访问，实现了Stack[T]接口。
+// !!! 与NodeStack一样，栈顶就是链表的第一个节点，Push和Pop都通过CAS替换栈顶指针来完成，CAS失败就重试。
+// LockFreeStack的“零值”是一个可用的空栈，但不能被拷贝。
+type LockFreeStack[T any] struct {
+	top    atomic.Pointer[lfNode[T]]
+	length atomic.Int64
+	policy NilPolicy
+}
+
+// NewLockFreeStack创建一个空的无锁栈，可以用WithNilPolicy选项设置入栈元素的检查策略。
+func NewLockFreeStack[T any](opts ...Option) *LockFreeStack[T] {
+	c := newConfig(opts)
+	return &LockFreeStack[T]{policy: c.nilPolicy}
+}
+
+func (lfs *LockFreeStack[T]) Push(item T) {
+	if checkValue(lfs.policy, item) != nil {
+		panic(PushNilValue)
+	}
+	lfs.push(item)
+}
+
+// PushErr与Push相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (lfs *LockFreeStack[T]) PushErr(item T) error {
+	if err := checkValue(lfs.policy, item); err != nil {
+		return err
+	}
+	lfs.push(item)
+	return nil
+}
+
+func (lfs *LockFreeStack[T]) push(item T) {
+	nd := &lfNode[T]{value: item}
+	for {
+		top := lfs.top.Load()
+		nd.next.Store(top)
+		if lfs.top.CompareAndSwap(top, nd) {
+			break
+		}
+	}
+	lfs.length.Add(1)
+}
+
+// TryPop弹出栈顶元素，栈为空时返回false。
+func (lfs *LockFreeStack[T]) TryPop() (T, bool) {
+	var zero T
+	for {
+		top := lfs.top.Load()
+		if top == nil {
+			return zero, false
+		}
+		if lfs.top.CompareAndSwap(top, top.next.Load()) {
+			lfs.length.Add(-1)
+			return top.value, true
+		}
+	}
+}
+
+// 弹出栈中元素，如果栈已经为空，则会抛出值为PopEmptyStack的panic。
+func (lfs *LockFreeStack[T]) Pop() T {
+	item, ok := lfs.TryPop()
+	if !ok {
+		panic(PopEmptyStack)
+	}
+	return item
+}
+
+// PopErr与Pop相同，但在栈为空时返回ErrEmpty，而不是抛出panic。
+func (lfs *LockFreeStack[T]) PopErr() (T, error) {
+	item, ok := lfs.TryPop()
+	if !ok {
+		return item, ErrEmpty
+	}
+	return item, nil
+}
+
+// TryTop读取栈顶元素，栈为空时返回false。
+func (lfs *LockFreeStack[T]) TryTop() (T, bool) {
+	var zero T
+	top := lfs.top.Load()
+	if top == nil {
+		return zero, false
+	}
+	return top.value, true
+}
+
+// 读取栈顶端元素，如果栈已经为空，则会抛出值为TopEmptyStack的panic
+func (lfs *LockFreeStack[T]) Top() T {
+	item, ok := lfs.TryTop()
+	if !ok {
+		panic(TopEmptyStack)
+	}
+	return item
+}
+
+func (lfs *LockFreeStack[T]) IsEmpty() bool {
+	return lfs.top.Load() == nil
+}
+
+// Size返回栈中元素的个数，在并发修改时只是一个近似值
+func (lfs *LockFreeStack[T]) Size() int {
+	return int(max(lfs.length.Load(), 0))
+}
+
+// All返回从栈顶到栈底遍历栈中元素的迭代器，遍历的是调用时栈的状态，之后其他goroutine的修改不会被看到。
+func (lfs *LockFreeStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for nd := lfs.top.Load(); nd != nil; nd = nd.next.Load() {
+			if !yield(nd.value) {
+				return
+			}
+		}
+	}
+}
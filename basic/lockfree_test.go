@@ -0,0 +1,193 @@
+package basic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLockFreeQueue(t *testing.T) {
+	var myQueue Queue[int] = NewLockFreeQueue[int]()
+	if _, ok := myQueue.(*LockFreeQueue[int]).TryRemove(); ok {
+		t.Errorf("空队列TryRemove应返回false")
+	}
+	for i := 0; i < 10; i++ {
+		myQueue.Insert(i)
+	}
+	if myQueue.First() != 0 || myQueue.Size() != 10 {
+		t.Errorf("队头应为0，元素个数应为10，实际为%d,%d", myQueue.First(), myQueue.Size())
+	}
+	i := 0
+	for it := myQueue.Iterator(); it.HasNext(); i++ {
+		if item := it.Next(); item != i {
+			t.Errorf("迭代器第%d个元素应为%d，实际为%d", i, i, item)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if item := myQueue.Remove(); item != i {
+			t.Errorf("第%d个移除的元素应为%d，实际为%d", i, i, item)
+		}
+	}
+	if !myQueue.IsEmpty() {
+		t.Errorf("队列应为空")
+	}
+}
+
+func TestLockFreeStack(t *testing.T) {
+	var myStack Stack[string] = &LockFreeStack[string]{}
+	myStack.Push("郑健")
+	myStack.Push("刘飞")
+	if myStack.Top() != "刘飞" || myStack.Pop() != "刘飞" || myStack.Pop() != "郑健" {
+		t.Errorf("栈应遵循后进先出的顺序")
+	}
+	if _, err := myStack.(*LockFreeStack[string]).PopErr(); err != ErrEmpty {
+		t.Errorf("空栈PopErr应返回ErrEmpty，实际为%v", err)
+	}
+}
+
+// !!! 多个goroutine并发地插入与移除，检查每个元素恰好被移除一次，需要以 go test -race 运行以检查数据竞争
+func TestLockFreeQueueConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 2000
+	lfq := NewLockFreeQueue[int]()
+	seen := make([]int32, goroutines*perGoroutine)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				lfq.Insert(g*perGoroutine + i)
+				if item, ok := lfq.TryRemove(); ok {
+					mu.Lock()
+					seen[item]++
+					mu.Unlock()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	for item, ok := lfq.TryRemove(); ok; item, ok = lfq.TryRemove() {
+		seen[item]++
+	}
+	for item, count := range seen {
+		if count != 1 {
+			t.Fatalf("元素%d被移除了%d次", item, count)
+		}
+	}
+	if lfq.Size() != 0 {
+		t.Errorf("队列取空后元素个数应为0，实际为%d", lfq.Size())
+	}
+}
+
+func TestLockFreeStackConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 2000
+	lfs := NewLockFreeStack[int]()
+	var wg sync.WaitGroup
+	sums := make([]int, goroutines)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 1; i <= perGoroutine; i++ {
+				lfs.Push(i)
+				if i%2 == 0 {
+					item, _ := lfs.TryPop()
+					sums[g] += item
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	total := 0
+	for _, sum := range sums {
+		total += sum
+	}
+	for item, ok := lfs.TryPop(); ok; item, ok = lfs.TryPop() {
+		total += item
+	}
+	if want := goroutines * perGoroutine * (perGoroutine + 1) / 2; total != want {
+		t.Errorf("弹出元素之和应为%d，实际为%d", want, total)
+	}
+}
+
+// mutexNodeQueue是以互斥锁保护的NodeQueue，作为无锁队列性能比较的基准
+type mutexNodeQueue[T any] struct {
+	mu    sync.Mutex
+	queue NodeQueue[T]
+}
+
+func (mq *mutexNodeQueue[T]) Insert(item T) {
+	mq.mu.Lock()
+	mq.queue.Insert(item)
+	mq.mu.Unlock()
+}
+func (mq *mutexNodeQueue[T]) TryRemove() (T, bool) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+	return mq.queue.TryRemove()
+}
+
+// mutexNodeStack是以互斥锁保护的NodeStack，作为无锁栈性能比较的基准
+type mutexNodeStack[T any] struct {
+	mu    sync.Mutex
+	stack NodeStack[T]
+}
+
+func (ms *mutexNodeStack[T]) Push(item T) {
+	ms.mu.Lock()
+	ms.stack.Push(item)
+	ms.mu.Unlock()
+}
+func (ms *mutexNodeStack[T]) TryPop() (T, bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.stack.TryPop()
+}
+
+// !!! 在多个goroutine竞争的情况下比较无锁队列与互斥锁保护的NodeQueue，
+// !!! 运行方式：go test -run '^$' -bench Contention -cpu 1,4,8
+func BenchmarkQueueContention(b *testing.B) {
+	queues := []struct {
+		name  string
+		queue interface {
+			Insert(item int)
+			TryRemove() (int, bool)
+		}
+	}{
+		{"MutexNodeQueue", &mutexNodeQueue[int]{}},
+		{"LockFreeQueue", NewLockFreeQueue[int]()},
+	}
+	for _, q := range queues {
+		b.Run(q.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					q.queue.Insert(i)
+					q.queue.TryRemove()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkStackContention(b *testing.B) {
+	stacks := []struct {
+		name  string
+		stack interface {
+			Push(item int)
+			TryPop() (int, bool)
+		}
+	}{
+		{"MutexNodeStack", &mutexNodeStack[int]{}},
+		{"LockFreeStack", NewLockFreeStack[int]()},
+	}
+	for _, s := range stacks {
+		b.Run(s.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					s.stack.Push(i)
+					s.stack.TryPop()
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package myheap
+
+// !!! 本包中的Init、Push、Pop、Remove、Fix处理的是二叉堆：节点i的父节点位于(i-1)/2，子节点位于2*i+1和2*i+2。
+// !!! 以下以D结尾的例程处理的是d叉堆（d-ary heap），语义与对应的二叉堆例程完全相同，只是每个节点最多有d个子节点：
+// !!! 节点i的父节点位于(i-1)/d，子节点位于d*i+1到d*i+d。
+// !!! d越大，树的高度log_d(n)越低，up（上浮）所需的比较次数越少，但down（下沉）每一层要在d个子节点中找出最小的一个。
+// !!! 因此d叉堆适合插入、减小键值（decrease-key）远多于弹出的场景，例如Dijkstra算法常用4叉堆；
+// !!! 此外，d个子节点在切片中是连续存放的，d取4或8时对CPU缓存也更友好。
+// !!! d必须不小于2，d为2时直接调用二叉堆例程。
+
+// checkArity检查d叉堆的d是否有效
+func checkArity(d int) {
+	if d < 2 {
+		panic("d叉堆的d必须不小于2")
+	}
+}
+
+// InitD建立d叉堆的不变性，复杂度为O(n)，这里n=h.Len()。
+func InitD(h MHeap, d int) {
+	checkArity(d)
+	if d == 2 {
+		Init(h)
+		return
+	}
+	n := h.Len()
+	//!!! (n-2)/d是最后一个元素（序号为n-1）的父节点，从它开始向前逐个做下沉处理
+	for i := (n - 2) / d; i >= 0; i-- {
+		downD(h, i, n, d)
+	}
+}
+
+// PushD将元素x加入d叉堆，复杂度为O(log_d n)。
+func PushD(h MHeap, x any, d int) {
+	checkArity(d)
+	if d == 2 {
+		Push(h, x)
+		return
+	}
+	h.Push(x)
+	upD(h, h.Len()-1, d)
+}
+
+// PopD移除并返回d叉堆中最小的元素，复杂度为O(d*log_d n)，等价于RemoveD(h, 0, d)。
+func PopD(h MHeap, d int) any {
+	checkArity(d)
+	if d == 2 {
+		return Pop(h)
+	}
+	n := h.Len() - 1
+	h.Swap(0, n)
+	downD(h, 0, n, d)
+	return h.Pop()
+}
+
+// RemoveD移除并返回d叉堆中位于序号i处的元素，复杂度为O(d*log_d n)。
+func RemoveD(h MHeap, i int, d int) any {
+	checkArity(d)
+	if d == 2 {
+		return Remove(h, i)
+	}
+	n := h.Len() - 1
+	if n != i {
+		h.Swap(i, n)
+		if !downD(h, i, n, d) {
+			upD(h, i, d)
+		}
+	}
+	return h.Pop()
+}
+
+// FixD在d叉堆中位于序号i处的元素的值发生变化之后重新建立堆的顺序。
+func FixD(h MHeap, i int, d int) {
+	checkArity(d)
+	if d == 2 {
+		Fix(h, i)
+		return
+	}
+	if !downD(h, i, h.Len(), d) {
+		upD(h, i, d)
+	}
+}
+
+// upD与up相同，只是父节点的位置为(j-1)/d
+func upD(h MHeap, j int, d int) {
+	for j > 0 {
+		i := (j - 1) / d // parent
+		if !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		j = i
+	}
+}
+
+// downD与down相同，只是要在位于d*i+1到d*i+d的（最多）d个子节点中找出最小的一个
+func downD(h MHeap, i0, n int, d int) bool {
+	i := i0
+	for {
+		first := d*i + 1
+		if first >= n || first < 0 { // first < 0 after int overflow
+			break
+		}
+		j := first //!!! j是最小的子节点
+		for k := first + 1; k < min(first+d, n); k++ {
+			if h.Less(k, j) {
+				j = k
+			}
+		}
+		if !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		i = j
+	}
+	return i > i0
+}
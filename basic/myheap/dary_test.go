@@ -0,0 +1,110 @@
+package myheap
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+var arities = []int{2, 3, 4, 8, 16}
+
+func TestDaryRoutines(t *testing.T) {
+	for _, d := range arities {
+		intHeap := &IntegerHeap{9, 4, 7, 1, 8, 2, 6, 3, 5}
+		InitD(intHeap, d)
+		PushD(intHeap, 0, d)
+		PushD(intHeap, 10, d)
+		(*intHeap)[3] = -1
+		FixD(intHeap, 3, d)
+		if x := RemoveD(intHeap, 5, d).(int); x < -1 || x > 10 {
+			t.Fatalf("d=%d: RemoveD返回了无效的元素%d", d, x)
+		}
+		var got []int
+		for intHeap.Len() > 0 {
+			got = append(got, PopD(intHeap, d).(int))
+		}
+		if !slices.IsSorted(got) {
+			t.Errorf("d=%d: PopD的结果应是有序的，实际为%v", d, got)
+		}
+	}
+}
+
+func TestDaryHeapRandom(t *testing.T) {
+	for _, d := range arities {
+		items := make([]int, 500)
+		for i := range items {
+			items[i] = rand.IntN(1000)
+		}
+		h := NewFromSliceWithArity(items, d, cmp.Less[int])
+		verify(t, h)
+		for k := 0; k < 500; k++ {
+			switch rand.IntN(4) {
+			case 0:
+				h.Push(rand.IntN(1000))
+			case 1:
+				h.Pop()
+			case 2:
+				h.Remove(rand.IntN(h.Len()))
+			case 3:
+				h.Set(rand.IntN(h.Len()), rand.IntN(1000))
+			}
+			verify(t, h)
+		}
+	}
+}
+
+func TestDaryInvalidArity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("d小于2时应抛出panic")
+		}
+	}()
+	NewWithArity(1, cmp.Less[int])
+}
+
+const benchHeapSize = 1_000_000
+
+// BenchmarkHeapArityPush比较不同d值的d叉堆在以插入为主的负载下的性能：先插入所有元素，再弹出1/10的元素。
+// !!! 插入时只需上浮，d越大树越矮，比较次数越少。
+func BenchmarkHeapArityPush(b *testing.B) {
+	items := make([]int, benchHeapSize)
+	for i := range items {
+		items[i] = rand.Int()
+	}
+	for _, d := range arities {
+		b.Run(fmt.Sprintf("d=%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := NewWithArity(d, cmp.Less[int])
+				for _, x := range items {
+					h.Push(x)
+				}
+				for k := 0; k < benchHeapSize/10; k++ {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkHeapArityPop比较不同d值的d叉堆在以弹出为主的负载下的性能：先堆化所有元素，再全部弹出。
+// !!! 弹出时需要下沉，每一层都要在d个子节点中找出最小的一个，d过大反而会变慢。
+func BenchmarkHeapArityPop(b *testing.B) {
+	items := make([]int, benchHeapSize)
+	for i := range items {
+		items[i] = rand.Int()
+	}
+	for _, d := range arities {
+		b.Run(fmt.Sprintf("d=%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				h := NewFromSliceWithArity(slices.Clone(items), d, cmp.Less[int])
+				b.StartTimer()
+				for h.Len() > 0 {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
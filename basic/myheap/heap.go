@@ -0,0 +1,117 @@
+package myheap
+
+// Heap是类型安全的泛型堆，堆顶是按照less函数排序最“小”的元素。
+// !!! MHeap接口模仿了container/heap，Push(x any)与Pop() any使得每个调用者都要做类型断言（参见myheap_test.go中的IntegerHeap）。
+// !!! Heap[T]在内部用实现了MHeap接口的heapData[T]适配本包的Init、Push、Pop、Remove、Fix等例程，
+// !!! 因此MHeap及其例程仍然是底层实现，Heap[T]只是其上的一层类型安全的封装。
+// !!! Heap默认是二叉堆，也可以用NewWithArity或NewFromSliceWithArity创建d叉堆（参见dary.go）。
+// Heap必须通过New、NewFromSlice、NewWithArity或NewFromSliceWithArity创建，并且不能被拷贝。
+type Heap[T any] struct {
+	data  heapData[T]
+	arity int // 每个节点最多的子节点个数
+}
+
+// heapData以切片为存储结构，实现了MHeap接口。
+type heapData[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (hd *heapData[T]) Len() int {
+	return len(hd.items)
+}
+func (hd *heapData[T]) Less(i, j int) bool {
+	return hd.less(hd.items[i], hd.items[j])
+}
+func (hd *heapData[T]) Swap(i, j int) {
+	hd.items[i], hd.items[j] = hd.items[j], hd.items[i]
+}
+func (hd *heapData[T]) Push(x any) {
+	hd.items = append(hd.items, x.(T))
+}
+func (hd *heapData[T]) Pop() any {
+	var zero T
+	n := len(hd.items) - 1
+	item := hd.items[n]
+	hd.items[n] = zero //!!! 清除对已移除元素的引用，以便垃圾回收
+	hd.items = hd.items[:n]
+	return item
+}
+
+// New创建一个空堆，less(a, b)为true表示a应排在b的前面（less为“<”时是最小堆，为“>”时是最大堆）。
+// 对于cmp.Ordered类型，可以使用cmp.Less[T]创建最小堆。
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return NewWithArity(2, less)
+}
+
+// NewWithArity创建一个空的d叉堆，d必须不小于2。
+func NewWithArity[T any](d int, less func(a, b T) bool) *Heap[T] {
+	checkArity(d)
+	return &Heap[T]{data: heapData[T]{less: less}, arity: d}
+}
+
+// NewFromSlice以items中的元素创建一个堆，堆化（heapify）的复杂度为O(n)，这里n=len(items)。
+// !!! 堆直接使用items作为存储结构，不会复制元素，创建堆之后调用者不应再修改items。
+func NewFromSlice[T any](items []T, less func(a, b T) bool) *Heap[T] {
+	return NewFromSliceWithArity(items, 2, less)
+}
+
+// NewFromSliceWithArity以items中的元素创建一个d叉堆，d必须不小于2。
+func NewFromSliceWithArity[T any](items []T, d int, less func(a, b T) bool) *Heap[T] {
+	checkArity(d)
+	h := &Heap[T]{data: heapData[T]{items: items, less: less}, arity: d}
+	InitD(&h.data, d) //!!! Init从最后一棵最小子树的根节点开始向前逐个做down处理
+	return h
+}
+
+// Push将元素x加入堆中，复杂度为O(log n)。
+func (h *Heap[T]) Push(x T) {
+	PushD(&h.data, x, h.arity)
+}
+
+// Pop移除并返回堆顶元素，复杂度为O(log n)，堆为空时抛出panic。
+func (h *Heap[T]) Pop() T {
+	if len(h.data.items) == 0 {
+		panic("堆已空，不能再弹出元素")
+	}
+	return PopD(&h.data, h.arity).(T)
+}
+
+// Peek返回堆顶元素而不移除它，堆为空时抛出panic。
+func (h *Heap[T]) Peek() T {
+	if len(h.data.items) == 0 {
+		panic("堆已空，无法读取堆顶元素")
+	}
+	return h.data.items[0]
+}
+
+// Remove移除并返回位于序号i处的元素，复杂度为O(log n)。
+func (h *Heap[T]) Remove(i int) T {
+	return RemoveD(&h.data, i, h.arity).(T)
+}
+
+// Fix在位于序号i处的元素的值（或者它的排序依据）发生变化之后重新建立堆的顺序，复杂度为O(log n)。
+func (h *Heap[T]) Fix(i int) {
+	FixD(&h.data, i, h.arity)
+}
+
+// At返回位于序号i处的元素，序号0处是堆顶元素，其他元素之间只满足堆的不变性，而不是有序的。
+func (h *Heap[T]) At(i int) T {
+	return h.data.items[i]
+}
+
+// Set将位于序号i处的元素替换为x，并重新建立堆的顺序，等价于修改元素后调用Fix(i)。
+func (h *Heap[T]) Set(i int, x T) {
+	h.data.items[i] = x
+	FixD(&h.data, i, h.arity)
+}
+
+// Arity返回堆中每个节点最多的子节点个数
+func (h *Heap[T]) Arity() int {
+	return h.arity
+}
+
+// Len返回堆中元素的个数
+func (h *Heap[T]) Len() int {
+	return len(h.data.items)
+}
@@ -0,0 +1,85 @@
+package myheap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// verify检查d叉堆的不变性：任何节点都不“小于”其父节点
+func verify[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+	for j := 1; j < h.Len(); j++ {
+		if i := (j - 1) / h.Arity(); h.data.less(h.At(j), h.At(i)) {
+			t.Fatalf("堆的不变性被破坏：序号%d的元素小于其父节点%d", j, i)
+		}
+	}
+}
+
+func TestGenericHeap(t *testing.T) {
+	h := New(cmp.Less[int])
+	for _, x := range []int{1, 4, 5, 2, 10, 3} {
+		h.Push(x)
+		verify(t, h)
+	}
+	if h.Peek() != 1 {
+		t.Errorf("Peek应为1，实际为%d", h.Peek())
+	}
+	var got []int
+	for h.Len() > 0 {
+		got = append(got, h.Pop())
+		verify(t, h)
+	}
+	if want := []int{1, 2, 3, 4, 5, 10}; !slices.Equal(got, want) {
+		t.Errorf("弹出顺序应为%v，实际为%v", want, got)
+	}
+
+	//!!! less为“>”时是最大堆
+	maxHeap := New(func(a, b string) bool { return a > b })
+	for _, s := range []string{"b", "d", "a", "c"} {
+		maxHeap.Push(s)
+	}
+	if top := maxHeap.Pop(); top != "d" {
+		t.Errorf("最大堆弹出的元素应为d，实际为%s", top)
+	}
+}
+
+func TestGenericHeapFromSliceFixRemove(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = rand.IntN(500)
+	}
+	h := NewFromSlice(items, cmp.Less[int])
+	verify(t, h)
+
+	//!!! 用Set修改若干元素（Set内部调用Fix），每次修改后堆的不变性都应成立
+	for k := 0; k < 100; k++ {
+		h.Set(rand.IntN(h.Len()), rand.IntN(500))
+		verify(t, h)
+	}
+	//!!! 移除任意位置的元素后，剩余元素应按顺序弹出
+	want := slices.Clone(h.data.items)
+	for k := 0; k < 100; k++ {
+		x := h.Remove(rand.IntN(h.Len()))
+		want = slices.Delete(want, slices.Index(want, x), slices.Index(want, x)+1)
+		verify(t, h)
+	}
+	slices.Sort(want)
+	got := make([]int, 0, h.Len())
+	for h.Len() > 0 {
+		got = append(got, h.Pop())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("弹出的元素应为有序的剩余元素")
+	}
+}
+
+func TestGenericHeapPopEmpty(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("空堆Pop应抛出panic")
+		}
+	}()
+	New(cmp.Less[int]).Pop()
+}
@@ -0,0 +1,150 @@
+package myheap
+
+import "errors"
+
+var (
+	ErrInvalidHandle = errors.New("myheap: 句柄无效")            // 句柄对应的元素已被移除，或者句柄不属于这个堆
+	ErrKeyIncreased  = errors.New("myheap: 新的优先级不能排在原优先级之后") // DecreaseKey的新优先级比原优先级更“大”
+)
+
+// IndexedHeap是可寻址（addressable）的索引堆，Push返回元素的句柄（Handle），
+// 之后可以通过句柄在O(log n)时间内修改元素的优先级（DecreaseKey、Update）或移除元素（Remove），
+// 在O(1)时间内判断元素是否仍在堆中（Contains）。
+// !!! Fix(h, i)要求调用者知道元素当前在堆中的位置i，但每次Swap都会改变元素的位置。
+// !!! IndexedHeap在每个句柄中记录元素在堆中的位置，相当于一个“句柄到位置”的映射，
+// !!! indexedData的Swap、Push、Pop在移动元素的同时更新这个映射，因此句柄总能找到元素当前的位置。
+// !!! 这正是Dijkstra最短路径算法和Prim最小生成树算法所需要的“减小键值（decrease-key）”操作。
+// IndexedHeap必须通过NewIndexed或NewIndexedWithArity创建，并且不能被拷贝。
+type IndexedHeap[T, P any] struct {
+	data  indexedData[T, P]
+	arity int
+}
+
+// Handle是IndexedHeap中元素的句柄，元素被移除之后句柄失效。
+type Handle[T, P any] struct {
+	value    T
+	priority P
+	index    int                // 元素在堆中的位置，元素被移除后为-1
+	owner    *IndexedHeap[T, P] // 元素所属的堆
+}
+
+// Value返回句柄所对应的元素
+func (h *Handle[T, P]) Value() T {
+	return h.value
+}
+
+// Priority返回句柄所对应元素的优先级
+func (h *Handle[T, P]) Priority() P {
+	return h.priority
+}
+
+// indexedData实现了MHeap接口，移动元素时同步更新句柄中记录的位置。
+type indexedData[T, P any] struct {
+	items []*Handle[T, P]
+	less  func(a, b P) bool
+}
+
+func (id *indexedData[T, P]) Len() int {
+	return len(id.items)
+}
+func (id *indexedData[T, P]) Less(i, j int) bool {
+	return id.less(id.items[i].priority, id.items[j].priority)
+}
+func (id *indexedData[T, P]) Swap(i, j int) {
+	id.items[i], id.items[j] = id.items[j], id.items[i]
+	id.items[i].index = i
+	id.items[j].index = j
+}
+func (id *indexedData[T, P]) Push(x any) {
+	handle := x.(*Handle[T, P])
+	handle.index = len(id.items)
+	id.items = append(id.items, handle)
+}
+func (id *indexedData[T, P]) Pop() any {
+	n := len(id.items) - 1
+	handle := id.items[n]
+	id.items[n] = nil //!!! 清除对已移除元素的引用，以便垃圾回收
+	id.items = id.items[:n]
+	handle.index = -1
+	return handle
+}
+
+// NewIndexed创建一个空的索引堆（二叉堆），less(a, b)为true表示优先级a排在优先级b的前面。
+// 对于cmp.Ordered类型的优先级，可以使用cmp.Less[P]创建最小堆。
+func NewIndexed[T, P any](less func(a, b P) bool) *IndexedHeap[T, P] {
+	return NewIndexedWithArity[T](2, less)
+}
+
+// NewIndexedWithArity创建一个空的d叉索引堆，d必须不小于2。
+func NewIndexedWithArity[T, P any](d int, less func(a, b P) bool) *IndexedHeap[T, P] {
+	checkArity(d)
+	return &IndexedHeap[T, P]{data: indexedData[T, P]{less: less}, arity: d}
+}
+
+// Push以给定的优先级将元素加入堆中，并返回元素的句柄，复杂度为O(log n)。
+func (ih *IndexedHeap[T, P]) Push(value T, priority P) *Handle[T, P] {
+	handle := &Handle[T, P]{value: value, priority: priority, owner: ih}
+	PushD(&ih.data, handle, ih.arity)
+	return handle
+}
+
+// Pop移除并返回堆顶元素及其优先级，复杂度为O(log n)，堆为空时抛出panic。
+func (ih *IndexedHeap[T, P]) Pop() (T, P) {
+	if len(ih.data.items) == 0 {
+		panic("堆已空，不能再弹出元素")
+	}
+	handle := PopD(&ih.data, ih.arity).(*Handle[T, P])
+	return handle.value, handle.priority
+}
+
+// Peek返回堆顶元素及其优先级而不移除它，堆为空时抛出panic。
+func (ih *IndexedHeap[T, P]) Peek() (T, P) {
+	if len(ih.data.items) == 0 {
+		panic("堆已空，无法读取堆顶元素")
+	}
+	return ih.data.items[0].value, ih.data.items[0].priority
+}
+
+// Contains判断句柄所对应的元素是否仍在这个堆中，复杂度为O(1)。
+func (ih *IndexedHeap[T, P]) Contains(handle *Handle[T, P]) bool {
+	return handle != nil && handle.owner == ih && handle.index >= 0
+}
+
+// DecreaseKey将句柄所对应元素的优先级修改为priority，新的优先级不能排在原优先级之后，复杂度为O(log n)。
+// 句柄无效时返回ErrInvalidHandle，新的优先级排在原优先级之后时返回ErrKeyIncreased。
+func (ih *IndexedHeap[T, P]) DecreaseKey(handle *Handle[T, P], priority P) error {
+	if !ih.Contains(handle) {
+		return ErrInvalidHandle
+	}
+	if ih.data.less(handle.priority, priority) {
+		return ErrKeyIncreased
+	}
+	handle.priority = priority
+	upD(&ih.data, handle.index, ih.arity) //!!! 优先级只会变“小”，只需上浮
+	return nil
+}
+
+// Update将句柄所对应元素的优先级修改为priority，新的优先级可以排在原优先级之前或之后，复杂度为O(log n)。
+// 句柄无效时返回ErrInvalidHandle。
+func (ih *IndexedHeap[T, P]) Update(handle *Handle[T, P], priority P) error {
+	if !ih.Contains(handle) {
+		return ErrInvalidHandle
+	}
+	handle.priority = priority
+	FixD(&ih.data, handle.index, ih.arity)
+	return nil
+}
+
+// Remove从堆中移除句柄所对应的元素，复杂度为O(log n)，句柄无效时返回ErrInvalidHandle。
+func (ih *IndexedHeap[T, P]) Remove(handle *Handle[T, P]) error {
+	if !ih.Contains(handle) {
+		return ErrInvalidHandle
+	}
+	RemoveD(&ih.data, handle.index, ih.arity)
+	return nil
+}
+
+// Len返回堆中元素的个数
+func (ih *IndexedHeap[T, P]) Len() int {
+	return len(ih.data.items)
+}
@@ -0,0 +1,118 @@
+package myheap
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	ih := NewIndexed[string](cmp.Less[int])
+	a := ih.Push("a", 5)
+	b := ih.Push("b", 3)
+	c := ih.Push("c", 8)
+	if v, p := ih.Peek(); v != "b" || p != 3 {
+		t.Errorf("Peek应为(b,3)，实际为(%s,%d)", v, p)
+	}
+	if err := ih.DecreaseKey(c, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := ih.DecreaseKey(a, 9); !errors.Is(err, ErrKeyIncreased) {
+		t.Errorf("增大优先级的DecreaseKey应返回ErrKeyIncreased，实际为%v", err)
+	}
+	if err := ih.Update(a, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := ih.Remove(b); err != nil {
+		t.Fatal(err)
+	}
+	if ih.Contains(b) || !ih.Contains(a) {
+		t.Errorf("Contains的结果错误")
+	}
+	if err := ih.Remove(b); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("重复Remove应返回ErrInvalidHandle，实际为%v", err)
+	}
+	if v, _ := ih.Pop(); v != "a" {
+		t.Errorf("Pop应为a，实际为%s", v)
+	}
+	if v, p := ih.Pop(); v != "c" || p != 1 {
+		t.Errorf("Pop应为(c,1)，实际为(%s,%d)", v, p)
+	}
+	other := NewIndexed[string](cmp.Less[int])
+	if other.Contains(other.Push("x", 1)) && ih.Contains(other.Push("y", 2)) {
+		t.Errorf("其他堆的句柄不应被Contains")
+	}
+}
+
+// edge是有向图中的一条带权边
+type edge struct {
+	to     int
+	weight int
+}
+
+// dijkstra用索引堆求从source出发到各顶点的最短距离，不可达的顶点距离为math.MaxInt。
+func dijkstra(graph [][]edge, source int, d int) []int {
+	dist := make([]int, len(graph))
+	handles := make([]*Handle[int, int], len(graph))
+	pq := NewIndexedWithArity[int](d, cmp.Less[int])
+	for v := range dist {
+		dist[v] = math.MaxInt
+	}
+	dist[source] = 0
+	handles[source] = pq.Push(source, 0)
+	for pq.Len() > 0 {
+		u, du := pq.Pop()
+		for _, e := range graph[u] {
+			if nd := du + e.weight; nd < dist[e.to] {
+				dist[e.to] = nd
+				if pq.Contains(handles[e.to]) {
+					pq.DecreaseKey(handles[e.to], nd)
+				} else {
+					handles[e.to] = pq.Push(e.to, nd)
+				}
+			}
+		}
+	}
+	return dist
+}
+
+// bellmanFord是最短路径的朴素算法，用来验证dijkstra的结果
+func bellmanFord(graph [][]edge, source int) []int {
+	dist := make([]int, len(graph))
+	for v := range dist {
+		dist[v] = math.MaxInt
+	}
+	dist[source] = 0
+	for range graph {
+		for u := range graph {
+			if dist[u] == math.MaxInt {
+				continue
+			}
+			for _, e := range graph[u] {
+				dist[e.to] = min(dist[e.to], dist[u]+e.weight)
+			}
+		}
+	}
+	return dist
+}
+
+func TestIndexedHeapDijkstra(t *testing.T) {
+	n := 200
+	graph := make([][]edge, n)
+	for u := range graph {
+		for k := 0; k < 5; k++ {
+			graph[u] = append(graph[u], edge{rand.IntN(n), rand.IntN(100)})
+		}
+	}
+	want := bellmanFord(graph, 0)
+	for _, d := range arities {
+		got := dijkstra(graph, 0, d)
+		for v := range want {
+			if got[v] != want[v] {
+				t.Fatalf("d=%d: 到顶点%d的最短距离应为%d，实际为%d", d, v, want[v], got[v])
+			}
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package myheap
+
+// Interface是各种泛型堆的共同接口，堆顶是按照各自的less函数排序最“小”的元素。
+// !!! 本包的Heap[T]、pairingheap包的配对堆和binomialheap包的二项堆都实现了这个接口，
+// !!! 调用者可以面向Interface[T]编程，并根据需要（例如是否需要高效的Meld合并操作）替换具体的实现。
+type Interface[T any] interface {
+	Push(x T) // 将元素x加入堆中
+	Pop() T   // 移除并返回堆顶元素，堆为空时抛出panic
+	Peek() T  // 返回堆顶元素而不移除它，堆为空时抛出panic
+	Len() int // 返回堆中元素的个数
+}
+
+var _ Interface[int] = (*Heap[int])(nil)
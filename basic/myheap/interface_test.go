@@ -0,0 +1,43 @@
+package myheap_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"datastructure/basic/binomialheap"
+	"datastructure/basic/myheap"
+	"datastructure/basic/pairingheap"
+)
+
+// heapSort只依赖myheap.Interface，可以使用任何一种堆的实现
+func heapSort(h myheap.Interface[int], items []int) []int {
+	for _, x := range items {
+		h.Push(x)
+	}
+	sorted := make([]int, 0, len(items))
+	for h.Len() > 0 {
+		sorted = append(sorted, h.Pop())
+	}
+	return sorted
+}
+
+func TestHeapImplementationsAreInterchangeable(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = rand.IntN(500)
+	}
+	want := slices.Sorted(slices.Values(items))
+	impls := map[string]myheap.Interface[int]{
+		"myheap.Heap":      myheap.New(cmp.Less[int]),
+		"myheap.Heap(d=4)": myheap.NewWithArity(4, cmp.Less[int]),
+		"pairingheap":      pairingheap.New(cmp.Less[int]),
+		"binomialheap":     binomialheap.New(cmp.Less[int]),
+	}
+	for name, h := range impls {
+		if got := heapSort(h, items); !slices.Equal(got, want) {
+			t.Errorf("%s: 堆排序的结果错误", name)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package myheap
+
+import "math/bits"
+
+// !!! 最小-最大堆（min-max heap）是一棵完全二叉树，按层交替为“最小层”和“最大层”，根节点所在的第0层是最小层：
+// !!! 最小层上的节点不大于其所有子孙节点，最大层上的节点不小于其所有子孙节点。
+// !!! 因此根节点是最小元素，最大元素是根节点的两个子节点中较大的一个，二者都可以在O(1)时间内读取，
+// !!! 插入和删除最小、最大元素的复杂度都是O(log n)，可以作为“双端优先级队列”使用，
+// !!! 无需同时维护一个最小堆和一个最大堆。
+// !!! 与二叉堆一样，这里的minMaxUp和minMaxDown只通过MHeap接口的Less和Swap操作元素。
+
+// isMinLevel判断序号为i的节点是否位于最小层：第k层的节点序号为[2^k-1, 2^(k+1)-1)，k为偶数时是最小层。
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// minMaxUp使新追加的位于序号j处的节点按照最小-最大堆的规则上浮
+func minMaxUp(h MHeap, j int) {
+	if j == 0 {
+		return
+	}
+	i := (j - 1) / 2 // parent
+	if isMinLevel(j) {
+		if h.Less(i, j) { //!!! 位于最小层的j比位于最大层的父节点还大，与父节点交换后沿最大层上浮
+			h.Swap(i, j)
+			upByLevel(h, i, func(a, b int) bool { return h.Less(b, a) })
+		} else {
+			upByLevel(h, j, h.Less)
+		}
+	} else {
+		if h.Less(j, i) { //!!! 位于最大层的j比位于最小层的父节点还小，与父节点交换后沿最小层上浮
+			h.Swap(i, j)
+			upByLevel(h, i, h.Less)
+		} else {
+			upByLevel(h, j, func(a, b int) bool { return h.Less(b, a) })
+		}
+	}
+}
+
+// upByLevel使节点j与其祖父节点（同为最小层或最大层）比较并上浮，before(a, b)为true表示a应在b之上。
+func upByLevel(h MHeap, j int, before func(a, b int) bool) {
+	for j > 2 { //!!! 序号大于2的节点才有祖父节点
+		g := ((j-1)/2 - 1) / 2 // grandparent
+		if !before(j, g) {
+			break
+		}
+		h.Swap(g, j)
+		j = g
+	}
+}
+
+// minMaxDown使位于序号i0处的节点按照最小-最大堆的规则下沉，n是堆中元素的个数。
+func minMaxDown(h MHeap, i0, n int) {
+	if isMinLevel(i0) {
+		downByLevel(h, i0, n, h.Less)
+	} else {
+		downByLevel(h, i0, n, func(a, b int) bool { return h.Less(b, a) })
+	}
+}
+
+// downByLevel使节点i在其子节点和孙子节点中下沉，before(a, b)为true表示a应在b之上：
+// 对最小层的节点before就是Less，对最大层的节点before是“大于”。
+func downByLevel(h MHeap, i, n int, before func(a, b int) bool) {
+	for {
+		//!!! 在i的（最多2个）子节点和（最多4个）孙子节点中找出最应该在上面的节点m
+		first := 2*i + 1
+		if first >= n || first < 0 { // first < 0 after int overflow
+			return
+		}
+		m := first
+		for _, k := range [...]int{first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if k < n && before(k, m) {
+				m = k
+			}
+		}
+		if !before(m, i) {
+			return
+		}
+		h.Swap(i, m)
+		if m <= first+1 { //!!! m是子节点，子节点没有与i同类的子孙层，下沉结束
+			return
+		}
+		//!!! m是孙子节点，交换后还要保证它不违反与其父节点（位于另一类层）的顺序
+		if p := (m - 1) / 2; before(p, m) {
+			h.Swap(p, m)
+		}
+		i = m
+	}
+}
+
+// MinMaxHeap是泛型的最小-最大堆，可以在O(1)时间内读取最小和最大元素，在O(log n)时间内插入和删除最小、最大元素。
+// MinMaxHeap必须通过NewMinMax或NewMinMaxFromSlice创建，并且不能被拷贝。
+type MinMaxHeap[T any] struct {
+	data heapData[T]
+}
+
+// NewMinMax创建一个空的最小-最大堆，less(a, b)为true表示a小于b。
+func NewMinMax[T any](less func(a, b T) bool) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{data: heapData[T]{less: less}}
+}
+
+// NewMinMaxFromSlice以items中的元素创建一个最小-最大堆，复杂度为O(n)。
+// !!! 堆直接使用items作为存储结构，不会复制元素，创建堆之后调用者不应再修改items。
+func NewMinMaxFromSlice[T any](items []T, less func(a, b T) bool) *MinMaxHeap[T] {
+	h := &MinMaxHeap[T]{data: heapData[T]{items: items, less: less}}
+	n := len(items)
+	//!!! 与Init一样，从最后一棵最小子树的根节点开始向前逐个做下沉处理
+	for i := n/2 - 1; i >= 0; i-- {
+		minMaxDown(&h.data, i, n)
+	}
+	return h
+}
+
+// PushItem将元素x加入堆中，复杂度为O(log n)。
+func (h *MinMaxHeap[T]) PushItem(x T) {
+	h.data.Push(x)
+	minMaxUp(&h.data, h.data.Len()-1)
+}
+
+// maxIndex返回最大元素的序号：堆中只有一个元素时是根节点，否则是根节点的两个子节点中较大的一个。
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch n := h.data.Len(); {
+	case n == 1:
+		return 0
+	case n == 2 || !h.data.Less(1, 2):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removeAt移除位于序号i处的元素：把最后一个元素移到i处，然后按照i所在的层下沉。
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	n := h.data.Len() - 1
+	if n != i {
+		h.data.Swap(i, n)
+		minMaxDown(&h.data, i, n)
+	}
+	return h.data.Pop().(T)
+}
+
+// PopMin移除并返回最小元素，复杂度为O(log n)，堆为空时抛出panic。
+func (h *MinMaxHeap[T]) PopMin() T {
+	if h.data.Len() == 0 {
+		panic("堆已空，不能再弹出元素")
+	}
+	return h.removeAt(0)
+}
+
+// PopMax移除并返回最大元素，复杂度为O(log n)，堆为空时抛出panic。
+func (h *MinMaxHeap[T]) PopMax() T {
+	if h.data.Len() == 0 {
+		panic("堆已空，不能再弹出元素")
+	}
+	return h.removeAt(h.maxIndex())
+}
+
+// PeekMin返回最小元素而不移除它，堆为空时抛出panic。
+func (h *MinMaxHeap[T]) PeekMin() T {
+	if h.data.Len() == 0 {
+		panic("堆已空，无法读取最小元素")
+	}
+	return h.data.items[0]
+}
+
+// PeekMax返回最大元素而不移除它，堆为空时抛出panic。
+func (h *MinMaxHeap[T]) PeekMax() T {
+	if h.data.Len() == 0 {
+		panic("堆已空，无法读取最大元素")
+	}
+	return h.data.items[h.maxIndex()]
+}
+
+// Len返回堆中元素的个数
+func (h *MinMaxHeap[T]) Len() int {
+	return h.data.Len()
+}
@@ -0,0 +1,79 @@
+package myheap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// verifyMinMax检查最小-最大堆的不变性：最小层的节点不大于其子孙节点，最大层的节点不小于其子孙节点
+func verifyMinMax(t *testing.T, h *MinMaxHeap[int]) {
+	t.Helper()
+	items := h.data.items
+	for j := 1; j < len(items); j++ {
+		for i := (j - 1) / 2; ; i = (i - 1) / 2 {
+			if isMinLevel(i) && items[j] < items[i] || !isMinLevel(i) && items[j] > items[i] {
+				t.Fatalf("最小-最大堆的不变性被破坏：序号%d的元素%d与其祖先%d的元素%d", j, items[j], i, items[i])
+			}
+			if i == 0 {
+				break
+			}
+		}
+	}
+}
+
+func TestMinMaxHeap(t *testing.T) {
+	h := NewMinMax(cmp.Less[int])
+	var want []int
+	for k := 0; k < 5000; k++ {
+		switch op := rand.IntN(3); {
+		case op == 0 || h.Len() == 0:
+			x := rand.IntN(1000)
+			h.PushItem(x)
+			want = append(want, x)
+		case op == 1:
+			got, i := h.PopMin(), slices.Index(want, slices.Min(want))
+			if got != want[i] {
+				t.Fatalf("PopMin应为%d，实际为%d", want[i], got)
+			}
+			want = slices.Delete(want, i, i+1)
+		default:
+			got, i := h.PopMax(), slices.Index(want, slices.Max(want))
+			if got != want[i] {
+				t.Fatalf("PopMax应为%d，实际为%d", want[i], got)
+			}
+			want = slices.Delete(want, i, i+1)
+		}
+		verifyMinMax(t, h)
+		if h.Len() > 0 && (h.PeekMin() != slices.Min(want) || h.PeekMax() != slices.Max(want)) {
+			t.Fatalf("PeekMin/PeekMax应为%d/%d，实际为%d/%d", slices.Min(want), slices.Max(want), h.PeekMin(), h.PeekMax())
+		}
+	}
+}
+
+func TestMinMaxHeapFromSlice(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 7, 100, 1001} {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = rand.IntN(100)
+		}
+		want := slices.Sorted(slices.Values(items))
+		h := NewMinMaxFromSlice(items, cmp.Less[int])
+		verifyMinMax(t, h)
+		//!!! 交替从两端弹出，结果应与排序后从两端取元素相同
+		for lo, hi := 0, n-1; lo <= hi; {
+			if x := h.PopMin(); x != want[lo] {
+				t.Fatalf("n=%d: PopMin应为%d，实际为%d", n, want[lo], x)
+			}
+			lo++
+			if lo > hi {
+				break
+			}
+			if x := h.PopMax(); x != want[hi] {
+				t.Fatalf("n=%d: PopMax应为%d，实际为%d", n, want[hi], x)
+			}
+			hi--
+		}
+	}
+}
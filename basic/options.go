@@ -0,0 +1,97 @@
+package basic
+
+import "reflect"
+
+// NilPolicy决定容器在插入元素时如何对待nil值和类型的“零值”。
+// !!! IsNil依赖反射（reflect.ValueOf）来判断任意类型的值是否为nil，这在频繁插入的热点路径上有一定的开销，
+// !!! 因此，对于确定不会插入nil值，或者允许插入nil值的场景，可以用AllowNil策略完全跳过反射检查。
+type NilPolicy int
+
+const (
+	DefaultNilPolicy NilPolicy = iota // 使用容器自身的默认策略，SliceStack默认为RejectZero，其余容器默认为RejectNil
+	RejectNil                         // 拒绝nil值（nil指针、函数、映射、切片、通道和接口）
+	AllowNil                          // 允许任何值，不做任何检查
+	RejectZero                        // 拒绝类型的“零值”，比如0、""以及所有的nil值
+)
+
+// config是容器在构造时可以设置的选项集合。
+type config struct {
+	nilPolicy NilPolicy
+	capacity  int  // 初始容量，仅对预先分配存储空间的容器有效
+	shrink    bool // 元素减少时是否收缩存储空间，仅对环形缓冲区容器有效
+}
+
+// Option是容器构造函数（NewXxx）的可选参数，用于在构造时设置容器的行为。
+type Option func(*config)
+
+// WithNilPolicy设置容器插入元素时对nil值和“零值”的处理策略。
+func WithNilPolicy(policy NilPolicy) Option {
+	return func(c *config) {
+		c.nilPolicy = policy
+	}
+}
+
+// WithCapacity设置容器的初始容量，避免容器在增长过程中反复重新分配存储空间。
+func WithCapacity(capacity int) Option {
+	return func(c *config) {
+		c.capacity = capacity
+	}
+}
+
+// WithShrink使容器在元素数量降到容量的1/4时将容量减半，以便归还不再需要的内存。
+func WithShrink() Option {
+	return func(c *config) {
+		c.shrink = true
+	}
+}
+
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// orDefault在策略为DefaultNilPolicy时，返回给定的容器默认策略。
+func (policy NilPolicy) orDefault(def NilPolicy) NilPolicy {
+	if policy == DefaultNilPolicy {
+		return def
+	}
+	return policy
+}
+
+// checkValue按照给定的策略检查将要插入容器的元素，元素被拒绝时返回ErrNilValue或ErrZeroValue。
+// 策略为DefaultNilPolicy时按RejectNil处理。
+func checkValue[T any](policy NilPolicy, item T) error {
+	switch policy {
+	case AllowNil:
+		return nil
+	case RejectZero:
+		if isZero(item) {
+			return ErrZeroValue
+		}
+	default:
+		if IsNil(item) {
+			return ErrNilValue
+		}
+	}
+	return nil
+}
+
+// checkValues按照给定的策略依次检查将要批量插入容器的元素，返回第一个被拒绝的元素所对应的错误。
+// !!! 批量插入在插入任何元素之前先检查所有元素，以保证要么全部插入，要么容器保持不变。
+func checkValues[T any](policy NilPolicy, items []T) error {
+	for _, item := range items {
+		if err := checkValue(policy, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isZero判断给定值是否为其类型的“零值”，nil接口值（Kind为Invalid）也被视为零值。
+func isZero[T any](t T) bool {
+	value := reflect.ValueOf(t)
+	return !value.IsValid() || value.IsZero()
+}
@@ -0,0 +1,87 @@
+package basic
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNilPolicy(t *testing.T) {
+	var nilPtr *int
+	one := 1
+	cases := []struct {
+		policy  NilPolicy
+		item    *int
+		wantErr error
+	}{
+		{DefaultNilPolicy, nilPtr, ErrNilValue},
+		{RejectNil, nilPtr, ErrNilValue},
+		{RejectNil, &one, nil},
+		{AllowNil, nilPtr, nil},
+		{RejectZero, nilPtr, ErrZeroValue},
+		{RejectZero, &one, nil},
+	}
+	for _, c := range cases {
+		stack := NewNodeStack[*int](WithNilPolicy(c.policy))
+		if err := stack.PushErr(c.item); !errors.Is(err, c.wantErr) {
+			t.Errorf("策略%d: NodeStack.PushErr应返回%v，实际为%v", c.policy, c.wantErr, err)
+		}
+		queue := NewSliceQueue[*int](WithNilPolicy(c.policy))
+		if err := queue.InsertErr(c.item); !errors.Is(err, c.wantErr) {
+			t.Errorf("策略%d: SliceQueue.InsertErr应返回%v，实际为%v", c.policy, c.wantErr, err)
+		}
+	}
+
+	//!!! SliceStack默认拒绝“零值”，AllowNil策略下则允许0入栈
+	intStack := NewSliceStack[int]()
+	intStack.Push(0)
+	if !intStack.IsEmpty() {
+		t.Errorf("SliceStack默认应静默丢弃零值")
+	}
+	intStack = NewSliceStack[int](WithNilPolicy(AllowNil))
+	intStack.Push(0)
+	if top, ok := intStack.TryTop(); !ok || top != 0 {
+		t.Errorf("AllowNil策略下0应能入栈，实际为(%d,%v)", top, ok)
+	}
+
+	//!!! 其他容器在RejectZero策略下拒绝0和""
+	anyStack := NewSliceStackAny[string](WithNilPolicy(RejectZero))
+	if err := anyStack.PushErr(""); !errors.Is(err, ErrZeroValue) {
+		t.Errorf("RejectZero策略下\"\"应被拒绝，实际为%v", err)
+	}
+	deque := NewSliceDeque[int](WithNilPolicy(RejectZero))
+	if err := deque.InsertBackErr(0); !errors.Is(err, ErrZeroValue) {
+		t.Errorf("RejectZero策略下0应被拒绝，实际为%v", err)
+	}
+
+	//!!! AllowNil策略下nil函数可以插入队列
+	funcQueue := NewNodeQueue[func()](WithNilPolicy(AllowNil))
+	funcQueue.Insert(nil)
+	if funcQueue.Size() != 1 {
+		t.Errorf("AllowNil策略下nil函数应能插入队列")
+	}
+	list := NewDoubleLinkedList[[]int](WithNilPolicy(AllowNil))
+	list.Append(nil)
+	if list.Size() != 1 {
+		t.Errorf("AllowNil策略下nil切片应能插入列表")
+	}
+	pq := NewPriorityQueue[*int](2, WithNilPolicy(AllowNil))
+	if err := pq.InsertErr(nil, 1); err != nil {
+		t.Errorf("AllowNil策略下nil应能插入优先级队列，实际为%v", err)
+	}
+}
+
+// !!! 比较RejectNil（使用反射检查）与AllowNil（跳过反射）两种策略下插入元素的开销
+func BenchmarkSliceQueueInsertNilPolicy(b *testing.B) {
+	for _, bc := range []struct {
+		name   string
+		policy NilPolicy
+	}{{"RejectNil", RejectNil}, {"AllowNil", AllowNil}} {
+		b.Run(bc.name, func(b *testing.B) {
+			queue := NewSliceQueue[*int](WithNilPolicy(bc.policy))
+			item := new(int)
+			for i := 0; i < b.N; i++ {
+				queue.Insert(item)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// pairingheap包实现了泛型的配对堆（pairing heap）。
+// !!! 配对堆是一棵多叉树，树根是按照less函数排序最“小”的元素，每个节点都不“小于”其父节点。
+// !!! 插入、合并（Meld）只需比较两个树根，把较大的树根作为较小树根的第一个子节点，复杂度为O(1)；
+// !!! 删除最小元素时，把树根的所有子树“两两配对”合并，再从右向左依次合并成一棵树，均摊复杂度为O(log n)；
+// !!! 减小键值（DecreaseKey）时，把节点所在的子树从树中剪下来，再与树根合并，均摊复杂度不超过O(log n)。
+// !!! 与数组实现的二叉堆相比，配对堆可以高效地合并两个堆，并且在实践中有很好的常数因子。
+package pairingheap
+
+import "datastructure/basic/myheap"
+
+// Node是配对堆中的节点，也是元素的句柄，Insert返回的句柄可以用于DecreaseKey。
+type Node[T any] struct {
+	value   T
+	child   *Node[T] // 第一个（最左边的）子节点
+	sibling *Node[T] // 右边的兄弟节点
+	prev    *Node[T] // 左边的兄弟节点，如果是最左边的子节点，则为父节点
+	inHeap  bool     // 节点是否仍在堆中
+}
+
+// Value返回节点中的元素
+func (n *Node[T]) Value() T {
+	return n.value
+}
+
+// Heap是泛型的配对堆，实现了myheap.Interface[T]接口。
+// Heap必须通过New创建，并且不能被拷贝。
+type Heap[T any] struct {
+	root *Node[T]
+	size int
+	less func(a, b T) bool
+}
+
+var _ myheap.Interface[int] = (*Heap[int])(nil)
+
+// New创建一个空的配对堆，less(a, b)为true表示a应排在b的前面。
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// meld合并两棵树，返回合并后的树根：较大的树根成为较小树根的第一个子节点。
+func (h *Heap[T]) meld(a, b *Node[T]) *Node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.value, a.value) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	a.prev, a.sibling = nil, nil
+	return a
+}
+
+// mergePairs以“两趟（two-pass）”的方式合并first及其右边的所有兄弟子树：
+// 第一趟从左向右两两合并，第二趟从右向左把合并的结果依次合并成一棵树。
+func (h *Heap[T]) mergePairs(first *Node[T]) *Node[T] {
+	var pairs []*Node[T]
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+		}
+		a.prev, a.sibling = nil, nil
+		if b != nil {
+			b.prev, b.sibling = nil, nil
+		}
+		pairs = append(pairs, h.meld(a, b))
+	}
+	var root *Node[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.meld(pairs[i], root)
+	}
+	return root
+}
+
+// Insert将元素x加入堆中，并返回元素的句柄，复杂度为O(1)。
+func (h *Heap[T]) Insert(x T) *Node[T] {
+	n := &Node[T]{value: x, inHeap: true}
+	h.root = h.meld(h.root, n)
+	h.size++
+	return n
+}
+
+// FindMin返回堆顶元素而不移除它，复杂度为O(1)，堆为空时抛出panic。
+func (h *Heap[T]) FindMin() T {
+	if h.root == nil {
+		panic("堆已空，无法读取堆顶元素")
+	}
+	return h.root.value
+}
+
+// DeleteMin移除并返回堆顶元素，均摊复杂度为O(log n)，堆为空时抛出panic。
+func (h *Heap[T]) DeleteMin() T {
+	if h.root == nil {
+		panic("堆已空，不能再弹出元素")
+	}
+	old := h.root
+	h.root = h.mergePairs(old.child)
+	old.child = nil
+	old.inHeap = false
+	h.size--
+	return old.value
+}
+
+// DecreaseKey将句柄n中的元素替换为x，x不能排在原元素之后。
+// 句柄对应的元素已被移除时返回myheap.ErrInvalidHandle，x排在原元素之后时返回myheap.ErrKeyIncreased。
+// !!! 句柄必须属于这个堆（或者已经被Meld合并到这个堆中的其他堆）。
+func (h *Heap[T]) DecreaseKey(n *Node[T], x T) error {
+	if n == nil || !n.inHeap {
+		return myheap.ErrInvalidHandle
+	}
+	if h.less(n.value, x) {
+		return myheap.ErrKeyIncreased
+	}
+	n.value = x
+	if n == h.root {
+		return nil
+	}
+	//!!! 把以n为根的子树从树中剪下来，再与树根合并
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+	h.root = h.meld(h.root, n)
+	return nil
+}
+
+// Meld将other中的所有元素合并到h中，复杂度为O(1)，合并之后other成为空堆。
+// other中元素的句柄在合并之后仍然有效，可以用于h的DecreaseKey。
+func (h *Heap[T]) Meld(other *Heap[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.meld(h.root, other.root)
+	h.size += other.size
+	other.root = nil
+	other.size = 0
+}
+
+// Len返回堆中元素的个数
+func (h *Heap[T]) Len() int {
+	return h.size
+}
+
+// 以下方法实现了myheap.Interface[T]接口。
+
+// Push与Insert相同，但不返回句柄
+func (h *Heap[T]) Push(x T) {
+	h.Insert(x)
+}
+
+// Pop等价于DeleteMin
+func (h *Heap[T]) Pop() T {
+	return h.DeleteMin()
+}
+
+// Peek等价于FindMin
+func (h *Heap[T]) Peek() T {
+	return h.FindMin()
+}
@@ -0,0 +1,92 @@
+package pairingheap
+
+import (
+	"cmp"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"datastructure/basic/myheap"
+)
+
+// 用随机的插入、删除最小元素、减小键值操作检验堆，参照结果为一个普通的切片
+func TestRandomOperations(t *testing.T) {
+	h := New(cmp.Less[int])
+	var handles []*Node[int]
+	var want []int
+	for k := 0; k < 5000; k++ {
+		switch op := rand.IntN(10); {
+		case op < 5 || h.Len() == 0:
+			x := rand.IntN(10000)
+			handles = append(handles, h.Insert(x))
+			want = append(want, x)
+		case op < 8:
+			got := h.DeleteMin()
+			i := slices.Index(want, slices.Min(want))
+			if got != want[i] {
+				t.Fatalf("DeleteMin应为%d，实际为%d", want[i], got)
+			}
+			want = slices.Delete(want, i, i+1)
+		default:
+			hd := handles[rand.IntN(len(handles))]
+			old := hd.Value()
+			err := h.DecreaseKey(hd, old-rand.IntN(100))
+			if i := slices.Index(want, old); err == nil {
+				want[i] = hd.Value()
+			} else if !errors.Is(err, myheap.ErrInvalidHandle) {
+				t.Fatalf("DecreaseKey返回了意外的错误%v", err)
+			}
+		}
+		if h.Len() != len(want) {
+			t.Fatalf("Len应为%d，实际为%d", len(want), h.Len())
+		}
+		if h.Len() > 0 && h.FindMin() != slices.Min(want) {
+			t.Fatalf("FindMin应为%d，实际为%d", slices.Min(want), h.FindMin())
+		}
+	}
+}
+
+func TestDecreaseKeyErrors(t *testing.T) {
+	h := New(cmp.Less[int])
+	a := h.Insert(5)
+	if err := h.DecreaseKey(a, 6); !errors.Is(err, myheap.ErrKeyIncreased) {
+		t.Errorf("增大键值应返回ErrKeyIncreased，实际为%v", err)
+	}
+	h.DeleteMin()
+	if err := h.DecreaseKey(a, 1); !errors.Is(err, myheap.ErrInvalidHandle) {
+		t.Errorf("已移除元素的句柄应返回ErrInvalidHandle，实际为%v", err)
+	}
+}
+
+func TestMeld(t *testing.T) {
+	a, b := New(cmp.Less[int]), New(cmp.Less[int])
+	var want []int
+	var handles []*Node[int]
+	for i := 0; i < 300; i++ {
+		x, y := rand.IntN(1000), rand.IntN(1000)
+		a.Insert(x)
+		handles = append(handles, b.Insert(y))
+		want = append(want, x, y)
+	}
+	a.Meld(b)
+	if b.Len() != 0 || a.Len() != len(want) {
+		t.Fatalf("Meld之后的元素个数错误：a=%d，b=%d", a.Len(), b.Len())
+	}
+	//!!! 被合并的堆中元素的句柄仍然有效
+	for _, hd := range handles[:50] {
+		i := slices.Index(want, hd.Value())
+		if err := a.DecreaseKey(hd, hd.Value()-1000); err != nil {
+			t.Fatal(err)
+		}
+		want[i] = hd.Value()
+	}
+	slices.Sort(want)
+	var got []int
+	for a.Len() > 0 {
+		got = append(got, a.DeleteMin())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Meld之后DeleteMin的结果应是所有元素的有序序列")
+	}
+}
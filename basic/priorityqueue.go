@@ -0,0 +1,118 @@
+package basic
+
+import "iter"
+
+// PriorityQueue是由多个SliceQueue组成的优先级队列，优先级为1到numberPriorities的整数，数值越小优先级越高，
+// 同一优先级的元素按照先进先出的顺序排队。
+type PriorityQueue[T any] struct {
+	q    []SliceQueue[T] // 队列的切片,切片的序号（index）表示所含队列元素的优先级。
+	size int
+}
+
+// NewPriorityQueue创建一个有numberPriorities个优先级的优先级队列，opts选项会作用于每个优先级的SliceQueue。
+func NewPriorityQueue[T any](numberPriorities int, opts ...Option) (pq PriorityQueue[T]) {
+	pq.q = make([]SliceQueue[T], numberPriorities)
+	for i := range pq.q {
+		pq.q[i] = NewSliceQueue[T](opts...)
+	}
+	return pq
+	/** 上面的代码等价于以下代码
+	pq = PriorityQueue[T]{
+		q:    make([]SliceQueue[T], numberPriorities),
+		size: 0}
+	**/
+}
+
+func (pq *PriorityQueue[T]) Insert(item T, priority int) {
+	pq.q[priority-1].Insert(item)
+	pq.size++
+}
+
+func (pq *PriorityQueue[T]) Remove() T {
+	if pq.size == 0 {
+		panic("队列已空，不能再移除元素")
+	}
+	var result T
+	for i := 0; i < len(pq.q); i++ {
+		if pq.q[i].Size() > 0 {
+			result = pq.q[i].Remove()
+			pq.size--
+			break
+		}
+	}
+	return result
+}
+
+func (pq *PriorityQueue[T]) First() T {
+	if pq.size == 0 {
+		panic("队列已空，无法获得头元素")
+	}
+	var result T
+	for i := 0; i < len(pq.q); i++ {
+		q := pq.q[i]
+		if q.Size() > 0 {
+			result = q.First()
+			break
+		}
+	}
+	return result
+}
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return pq.size == 0
+}
+
+// InsertErr与Insert相同，但在优先级超出[1,numberPriorities]范围时返回ErrOutOfRange，
+// 在元素为nil时返回ErrNilValue，而不是抛出panic。
+func (pq *PriorityQueue[T]) InsertErr(item T, priority int) error {
+	if priority < 1 || priority > len(pq.q) {
+		return ErrOutOfRange
+	}
+	if err := pq.q[priority-1].InsertErr(item); err != nil {
+		return err
+	}
+	pq.size++
+	return nil
+}
+
+// RemoveErr与Remove相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (pq *PriorityQueue[T]) RemoveErr() (T, error) {
+	var zero T
+	if pq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return pq.Remove(), nil
+}
+
+// TryRemove移除优先级最高的元素，队列为空时返回false。
+func (pq *PriorityQueue[T]) TryRemove() (T, bool) {
+	item, err := pq.RemoveErr()
+	return item, err == nil
+}
+
+// FirstErr与First相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (pq *PriorityQueue[T]) FirstErr() (T, error) {
+	var zero T
+	if pq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return pq.First(), nil
+}
+
+// TryFirst读取优先级最高的元素，队列为空时返回false。
+func (pq *PriorityQueue[T]) TryFirst() (T, bool) {
+	item, err := pq.FirstErr()
+	return item, err == nil
+}
+
+// All按照元素被移除的顺序（优先级从高到低，同一优先级先进先出）遍历队列中的元素
+func (pq *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range pq.q {
+			for item := range pq.q[i].All() {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
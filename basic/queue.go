@@ -0,0 +1,314 @@
+package basic
+
+import "iter"
+
+// Queue泛型接口提取了所有（先进先出）队列类型的共同性操作。
+type Queue[T any] interface {
+	Insert(item T)         //队列插入元素只能在尾部追加
+	Remove() T             //队列的移除元素要从头部移除
+	First() T              //读取队列头部的元素
+	Size() int             //读取队列元素的个数
+	Iterator() Iterator[T] //以队列当前的状态创建一个迭代器
+	IsEmpty() bool         //判断队列是否为空
+}
+
+// Iterator泛型接口是对容器元素的顺序访问。
+type Iterator[T any] interface {
+	HasNext() bool
+	Next() T
+}
+
+// SliceQueue是以切片为存储结构的队列。
+type SliceQueue[T any] struct {
+	items    []T //!!!注意，含有切片的数据结构，要注意这样的类型所绑定的方法最好用指针访问，否则会有大量数据拷贝
+	modCount int //插入、删除元素的次数，用于迭代器检测并发修改
+	policy   NilPolicy
+}
+
+// NewSliceQueue创建一个空的SliceQueue，可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewSliceQueue[T any](opts ...Option) SliceQueue[T] {
+	c := newConfig(opts)
+	return SliceQueue[T]{policy: c.nilPolicy}
+}
+
+func (sq *SliceQueue[T]) Insert(item T) {
+	if checkValue(sq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	sq.items = append(sq.items, item)
+	sq.modCount++
+}
+func (sq *SliceQueue[T]) Remove() T {
+	l := len(sq.items)
+	if l == 0 {
+		panic("队列已空，不能再删除元素")
+	}
+	item := sq.items[0]
+	sq.items = sq.items[1:]
+	sq.modCount++
+	return item
+}
+func (sq *SliceQueue[T]) First() T {
+	if len(sq.items) == 0 {
+		panic("队列已空，无法读取第一个元素")
+	}
+	return sq.items[0]
+}
+
+func (sq *SliceQueue[T]) Size() int {
+	return len(sq.items)
+}
+func (sq *SliceQueue[T]) IsEmpty() bool {
+	return len(sq.items) == 0
+}
+
+// InsertErr与Insert相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (sq *SliceQueue[T]) InsertErr(item T) error {
+	if err := checkValue(sq.policy, item); err != nil {
+		return err
+	}
+	sq.items = append(sq.items, item)
+	sq.modCount++
+	return nil
+}
+
+// RemoveErr与Remove相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (sq *SliceQueue[T]) RemoveErr() (T, error) {
+	var zero T
+	if len(sq.items) == 0 {
+		return zero, ErrEmpty
+	}
+	item := sq.items[0]
+	sq.items = sq.items[1:]
+	sq.modCount++
+	return item, nil
+}
+
+// TryRemove移除队头元素，队列为空时返回false。
+func (sq *SliceQueue[T]) TryRemove() (T, bool) {
+	item, err := sq.RemoveErr()
+	return item, err == nil
+}
+
+// FirstErr与First相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (sq *SliceQueue[T]) FirstErr() (T, error) {
+	var zero T
+	if len(sq.items) == 0 {
+		return zero, ErrEmpty
+	}
+	return sq.items[0], nil
+}
+
+// TryFirst读取队头元素，队列为空时返回false。
+func (sq *SliceQueue[T]) TryFirst() (T, bool) {
+	item, err := sq.FirstErr()
+	return item, err == nil
+}
+
+// Iterator以队列当前的状态创建一个“快速失败”的迭代器：迭代器创建之后如果队列被插入或删除了元素，
+// 迭代器的Next方法会抛出值为ErrConcurrentModification的panic。
+func (sq *SliceQueue[T]) Iterator() Iterator[T] {
+	itrt := queueIterator[T]{
+		indexOfNext:      0,
+		items:            sq.items,
+		sq:               sq,
+		expectedModCount: sq.modCount,
+	}
+	return &itrt
+}
+
+// All返回从队头到队尾遍历队列元素的迭代器，遍历过程中如果队列被修改，会抛出值为ErrConcurrentModification的panic。
+func (sq *SliceQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		expectedModCount := sq.modCount
+		for _, item := range sq.items {
+			if !yield(item) {
+				return
+			}
+			checkModCount(sq.modCount, expectedModCount)
+		}
+	}
+}
+
+// Backward返回从队尾到队头遍历队列元素的迭代器，与All一样会检测并发修改。
+func (sq *SliceQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		expectedModCount := sq.modCount
+		for i := len(sq.items) - 1; i >= 0; i-- {
+			if !yield(sq.items[i]) {
+				return
+			}
+			checkModCount(sq.modCount, expectedModCount)
+		}
+	}
+}
+
+type queueIterator[T any] struct {
+	indexOfNext      int
+	items            []T            //!!!此实现中，由于item不是指针(*[]T)，这会导致数据的拷贝
+	sq               *SliceQueue[T] //迭代器所属的队列，为nil时（例如遍历快照时）不检测并发修改
+	expectedModCount int            //创建迭代器时队列的修改次数
+}
+
+func (qi *queueIterator[T]) HasNext() bool {
+	l := len(qi.items)
+	return qi.indexOfNext <= l-1
+}
+func (qi *queueIterator[T]) Next() T {
+	if qi.sq != nil {
+		checkModCount(qi.sq.modCount, qi.expectedModCount)
+	}
+	if !qi.HasNext() {
+		panic("迭代器已经没有下一个元素了！")
+	}
+	next := qi.items[qi.indexOfNext]
+	qi.indexOfNext += 1
+	return next
+}
+
+// NodeQueue是以单向链表节点（Node）为存储结构的队列，first为队头，last为队尾。
+type NodeQueue[T any] struct {
+	first, last *Node[T]
+	length      int
+	modCount    int //插入、删除元素的次数，用于迭代器检测并发修改
+	policy      NilPolicy
+}
+
+// NewNodeQueue创建一个空的NodeQueue，可以用WithNilPolicy选项设置插入元素的检查策略。
+func NewNodeQueue[T any](opts ...Option) NodeQueue[T] {
+	c := newConfig(opts)
+	return NodeQueue[T]{policy: c.nilPolicy}
+}
+
+// 队列插入元素只能在尾部追加
+func (nq *NodeQueue[T]) Insert(item T) {
+	if checkValue(nq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	nq.insertNode(item)
+}
+
+// insertNode不做任何检查，直接将元素作为新节点追加到队尾
+func (nq *NodeQueue[T]) insertNode(item T) {
+	nd := &Node[T]{value: item, next: nil}
+	if nq.first == nil {
+		nq.first = nd
+		nq.last = nd
+	} else {
+		nq.last.next = nd
+		nq.last = nd
+	}
+	nq.length += 1
+	nq.modCount++
+}
+
+// 队列的移除元素要从头部移除
+func (nq *NodeQueue[T]) Remove() T {
+	if nq.length == 0 {
+		panic("队列已空，不能再删除元素")
+	}
+	result := nq.first.value
+	nq.first = nq.first.next
+	if nq.first == nil {
+		nq.last = nil
+	}
+	nq.length -= 1
+	nq.modCount++
+	return result
+
+}
+
+// 读取队列头部的元素
+func (nq NodeQueue[T]) First() T {
+	if nq.length == 0 {
+		panic("队列已空，无法读取第一个元素")
+	}
+	return nq.first.value
+}
+
+// 读取队列元素的个数
+func (nq *NodeQueue[T]) Size() int {
+	return nq.length
+}
+
+// 以队列当前的状态创建一个“快速失败”的迭代器，迭代器创建之后如果队列被插入或删除了元素，
+// 迭代器的Next方法会抛出值为ErrConcurrentModification的panic。
+func (nq *NodeQueue[T]) Iterator() Iterator[T] {
+	return &nodeQueueIterator[T]{nextNode: nq.first, nq: nq, expectedModCount: nq.modCount}
+}
+
+// 判断队列是否为空
+func (nq *NodeQueue[T]) IsEmpty() bool {
+	return nq.length == 0
+}
+
+// InsertErr与Insert相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (nq *NodeQueue[T]) InsertErr(item T) error {
+	if err := checkValue(nq.policy, item); err != nil {
+		return err
+	}
+	nq.insertNode(item)
+	return nil
+}
+
+// RemoveErr与Remove相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (nq *NodeQueue[T]) RemoveErr() (T, error) {
+	var zero T
+	if nq.length == 0 {
+		return zero, ErrEmpty
+	}
+	return nq.Remove(), nil
+}
+
+// TryRemove移除队头元素，队列为空时返回false。
+func (nq *NodeQueue[T]) TryRemove() (T, bool) {
+	item, err := nq.RemoveErr()
+	return item, err == nil
+}
+
+// FirstErr与First相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (nq *NodeQueue[T]) FirstErr() (T, error) {
+	var zero T
+	if nq.length == 0 {
+		return zero, ErrEmpty
+	}
+	return nq.first.value, nil
+}
+
+// TryFirst读取队头元素，队列为空时返回false。
+func (nq *NodeQueue[T]) TryFirst() (T, bool) {
+	item, err := nq.FirstErr()
+	return item, err == nil
+}
+
+// All返回从队头到队尾遍历队列元素的迭代器，遍历过程中如果队列被修改，会抛出值为ErrConcurrentModification的panic。
+func (nq *NodeQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		expectedModCount := nq.modCount
+		for nd := nq.first; nd != nil; nd = nd.next {
+			if !yield(nd.value) {
+				return
+			}
+			checkModCount(nq.modCount, expectedModCount)
+		}
+	}
+}
+
+type nodeQueueIterator[T any] struct {
+	nextNode         *Node[T]
+	nq               *NodeQueue[T]
+	expectedModCount int
+}
+
+func (nqi *nodeQueueIterator[T]) HasNext() bool {
+	return nqi.nextNode != nil
+}
+func (nqi *nodeQueueIterator[T]) Next() T {
+	checkModCount(nqi.nq.modCount, nqi.expectedModCount)
+	if !nqi.HasNext() {
+		panic("迭代器已经没有下一个元素了！")
+	}
+	result := nqi.nextNode.value
+	nqi.nextNode = nqi.nextNode.next
+	return result
+}
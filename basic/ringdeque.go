@@ -0,0 +1,410 @@
+package basic
+
+import (
+	"iter"
+	"slices"
+)
+
+// minRingCapacity是环形缓冲区的最小容量，容量总是2的整数次幂，以便用位运算代替取模运算。
+const minRingCapacity = 8
+
+// RingDeque是以可增长的环形缓冲区（circular buffer）为存储结构的双端队列，同时实现了Deque[T]与Queue[T]接口。
+// !!! SliceQueue的Remove通过items[1:]重新切片来移除队头元素，被移除元素所占的底层数组头部空间无法被复用，
+// !!! SliceDeque的InsertFront则需要移动所有元素。RingDeque用head记录队头在缓冲区中的位置，
+// !!! 两端的插入与移除都只需移动head或计算队尾位置，均摊复杂度为O(1)，缓冲区满时容量翻倍，并复用已移除元素的空间。
+// !!! 如果构造时使用了WithShrink选项，当元素个数降到容量的1/4时容量减半。
+// RingDeque的“零值”是一个可用的空队列。
+type RingDeque[T any] struct {
+	buf    []T // 环形缓冲区，len(buf)总是0或2的整数次幂
+	head   int // 队头元素在buf中的位置
+	size   int // 队列中元素的个数
+	minCap int // 收缩时不会低于的容量
+	shrink bool
+	policy NilPolicy
+}
+
+// NewRingDeque创建一个空的RingDeque，可以用WithCapacity选项设置初始容量，
+// 用WithShrink选项开启收缩策略，用WithNilPolicy选项设置插入元素的检查策略。
+func NewRingDeque[T any](opts ...Option) RingDeque[T] {
+	c := newConfig(opts)
+	rdq := RingDeque[T]{shrink: c.shrink, policy: c.nilPolicy, minCap: minRingCapacity}
+	if c.capacity > 0 {
+		rdq.minCap = ceilPowerOfTwo(c.capacity)
+		rdq.buf = make([]T, rdq.minCap)
+	}
+	return rdq
+}
+
+// ceilPowerOfTwo返回不小于n的最小的2的整数次幂（至少为minRingCapacity）。
+func ceilPowerOfTwo(n int) int {
+	c := minRingCapacity
+	for c < n {
+		c <<= 1
+	}
+	return c
+}
+
+// index将第i个元素（从队头开始计数）映射为其在缓冲区中的位置
+func (rdq *RingDeque[T]) index(i int) int {
+	return (rdq.head + i) & (len(rdq.buf) - 1)
+}
+
+// resize将缓冲区的容量调整为capacity，并将元素依次搬移到新缓冲区的开头。
+func (rdq *RingDeque[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	if rdq.size > 0 {
+		//!!! 元素在旧缓冲区中可能分为两段：[head,len(buf)) 和 [0,tail)
+		n := copy(buf, rdq.buf[rdq.head:min(rdq.head+rdq.size, len(rdq.buf))])
+		copy(buf[n:], rdq.buf[:rdq.size-n])
+	}
+	rdq.buf = buf
+	rdq.head = 0
+}
+
+// grow在缓冲区已满时将容量翻倍
+func (rdq *RingDeque[T]) grow() {
+	if rdq.size < len(rdq.buf) {
+		return
+	}
+	if len(rdq.buf) == 0 {
+		rdq.resize(max(rdq.minCap, minRingCapacity))
+		return
+	}
+	rdq.resize(len(rdq.buf) * 2)
+}
+
+// shrinkIfNeeded在开启收缩策略时，如果元素个数降到容量的1/4，就将容量减半
+func (rdq *RingDeque[T]) shrinkIfNeeded() {
+	if rdq.shrink && len(rdq.buf) > max(rdq.minCap, minRingCapacity) && rdq.size <= len(rdq.buf)/4 {
+		rdq.resize(len(rdq.buf) / 2)
+	}
+}
+
+func (rdq *RingDeque[T]) InsertFront(item T) {
+	if checkValue(rdq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	rdq.insertFront(item)
+}
+
+// insertFront不做任何检查，直接将元素插入到队头
+func (rdq *RingDeque[T]) insertFront(item T) {
+	rdq.grow()
+	rdq.head = (rdq.head - 1) & (len(rdq.buf) - 1)
+	rdq.buf[rdq.head] = item
+	rdq.size++
+}
+
+func (rdq *RingDeque[T]) InsertBack(item T) {
+	if checkValue(rdq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	rdq.insertBack(item)
+}
+
+// insertBack不做任何检查，直接将元素追加到队尾
+func (rdq *RingDeque[T]) insertBack(item T) {
+	rdq.grow()
+	rdq.buf[rdq.index(rdq.size)] = item
+	rdq.size++
+}
+
+func (rdq *RingDeque[T]) RemoveFirst() T {
+	if rdq.size == 0 {
+		panic("队列已空，不能再删除元素")
+	}
+	var zero T
+	result := rdq.buf[rdq.head]
+	rdq.buf[rdq.head] = zero //!!! 清除对已移除元素的引用，以便垃圾回收
+	rdq.head = rdq.index(1)
+	rdq.size--
+	rdq.shrinkIfNeeded()
+	return result
+}
+
+func (rdq *RingDeque[T]) RemoveLast() T {
+	if rdq.size == 0 {
+		panic("队列已空，不能再删除元素")
+	}
+	var zero T
+	i := rdq.index(rdq.size - 1)
+	result := rdq.buf[i]
+	rdq.buf[i] = zero //!!! 清除对已移除元素的引用，以便垃圾回收
+	rdq.size--
+	rdq.shrinkIfNeeded()
+	return result
+}
+
+func (rdq *RingDeque[T]) First() T {
+	if rdq.size == 0 {
+		panic("队列已空，无法读取第一个元素")
+	}
+	return rdq.buf[rdq.head]
+}
+
+func (rdq *RingDeque[T]) Last() T {
+	if rdq.size == 0 {
+		panic("队列已空，无法读取最后一个元素")
+	}
+	return rdq.buf[rdq.index(rdq.size-1)]
+}
+
+func (rdq *RingDeque[T]) IsEmpty() bool {
+	return rdq.size == 0
+}
+
+func (rdq *RingDeque[T]) Size() int {
+	return rdq.size
+}
+
+// Cap返回环形缓冲区当前的容量
+func (rdq *RingDeque[T]) Cap() int {
+	return len(rdq.buf)
+}
+
+// 以下方法使RingDeque同时实现了Queue[T]接口：在队尾插入，从队头移除。
+
+// Insert将元素追加到队尾，等价于InsertBack
+func (rdq *RingDeque[T]) Insert(item T) {
+	rdq.InsertBack(item)
+}
+
+// Remove移除队头元素，等价于RemoveFirst
+func (rdq *RingDeque[T]) Remove() T {
+	return rdq.RemoveFirst()
+}
+
+// 以队列当前的状态创建一个迭代器
+func (rdq *RingDeque[T]) Iterator() Iterator[T] {
+	return &ringIterator[T]{rdq: rdq}
+}
+
+// All返回从队头到队尾遍历队列元素的迭代器
+func (rdq *RingDeque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < rdq.size; i++ {
+			if !yield(rdq.buf[rdq.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward返回从队尾到队头遍历队列元素的迭代器
+func (rdq *RingDeque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := rdq.size - 1; i >= 0; i-- {
+			if !yield(rdq.buf[rdq.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+type ringIterator[T any] struct {
+	rdq         *RingDeque[T]
+	indexOfNext int
+}
+
+func (ri *ringIterator[T]) HasNext() bool {
+	return ri.indexOfNext < ri.rdq.size
+}
+func (ri *ringIterator[T]) Next() T {
+	if !ri.HasNext() {
+		panic("迭代器已经没有下一个元素了！")
+	}
+	next := ri.rdq.buf[ri.rdq.index(ri.indexOfNext)]
+	ri.indexOfNext += 1
+	return next
+}
+
+// InsertFrontErr与InsertFront相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (rdq *RingDeque[T]) InsertFrontErr(item T) error {
+	if err := checkValue(rdq.policy, item); err != nil {
+		return err
+	}
+	rdq.insertFront(item)
+	return nil
+}
+
+// InsertBackErr与InsertBack相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (rdq *RingDeque[T]) InsertBackErr(item T) error {
+	if err := checkValue(rdq.policy, item); err != nil {
+		return err
+	}
+	rdq.insertBack(item)
+	return nil
+}
+
+// InsertErr等价于InsertBackErr
+func (rdq *RingDeque[T]) InsertErr(item T) error {
+	return rdq.InsertBackErr(item)
+}
+
+// RemoveFirstErr与RemoveFirst相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (rdq *RingDeque[T]) RemoveFirstErr() (T, error) {
+	var zero T
+	if rdq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return rdq.RemoveFirst(), nil
+}
+
+// TryRemoveFirst移除队头元素，队列为空时返回false。
+func (rdq *RingDeque[T]) TryRemoveFirst() (T, bool) {
+	item, err := rdq.RemoveFirstErr()
+	return item, err == nil
+}
+
+// RemoveErr等价于RemoveFirstErr
+func (rdq *RingDeque[T]) RemoveErr() (T, error) {
+	return rdq.RemoveFirstErr()
+}
+
+// TryRemove等价于TryRemoveFirst
+func (rdq *RingDeque[T]) TryRemove() (T, bool) {
+	return rdq.TryRemoveFirst()
+}
+
+// RemoveLastErr与RemoveLast相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (rdq *RingDeque[T]) RemoveLastErr() (T, error) {
+	var zero T
+	if rdq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return rdq.RemoveLast(), nil
+}
+
+// TryRemoveLast移除队尾元素，队列为空时返回false。
+func (rdq *RingDeque[T]) TryRemoveLast() (T, bool) {
+	item, err := rdq.RemoveLastErr()
+	return item, err == nil
+}
+
+// FirstErr与First相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (rdq *RingDeque[T]) FirstErr() (T, error) {
+	var zero T
+	if rdq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return rdq.buf[rdq.head], nil
+}
+
+// TryFirst读取队头元素，队列为空时返回false。
+func (rdq *RingDeque[T]) TryFirst() (T, bool) {
+	item, err := rdq.FirstErr()
+	return item, err == nil
+}
+
+// LastErr与Last相同，但在队列为空时返回ErrEmpty，而不是抛出panic。
+func (rdq *RingDeque[T]) LastErr() (T, error) {
+	var zero T
+	if rdq.size == 0 {
+		return zero, ErrEmpty
+	}
+	return rdq.buf[rdq.index(rdq.size-1)], nil
+}
+
+// TryLast读取队尾元素，队列为空时返回false。
+func (rdq *RingDeque[T]) TryLast() (T, bool) {
+	item, err := rdq.LastErr()
+	return item, err == nil
+}
+
+// At读取序号为i的元素，复杂度为O(1)
+func (rdq *RingDeque[T]) At(i int) T {
+	checkIndex(i, rdq.size)
+	return rdq.buf[rdq.index(i)]
+}
+
+// Set替换序号为i的元素，新元素同样要经过检查策略的检查
+func (rdq *RingDeque[T]) Set(i int, item T) {
+	checkIndex(i, rdq.size)
+	if checkValue(rdq.policy, item) != nil {
+		panic("空值不允许插入到队列")
+	}
+	rdq.buf[rdq.index(i)] = item
+}
+
+// Rotate向队尾方向循环移动k步，复杂度为O(min(k, Size()-k))。
+// !!! 缓冲区已满时队尾紧挨着队头，只需移动head；否则每次把一端的元素搬到另一端的空闲位置，
+// !!! 选择搬移元素较少的方向。
+func (rdq *RingDeque[T]) Rotate(k int) {
+	k = normalizeRotation(k, rdq.size)
+	if k == 0 {
+		return
+	}
+	mask := len(rdq.buf) - 1
+	if rdq.size == len(rdq.buf) {
+		rdq.head = (rdq.head - k) & mask
+		return
+	}
+	var zero T
+	if k <= rdq.size/2 {
+		for range k { //!!! 队尾元素移到队头
+			tail := rdq.index(rdq.size - 1)
+			rdq.head = (rdq.head - 1) & mask
+			rdq.buf[rdq.head], rdq.buf[tail] = rdq.buf[tail], zero
+		}
+		return
+	}
+	for range rdq.size - k { //!!! 队头元素移到队尾
+		rdq.buf[rdq.index(rdq.size)], rdq.buf[rdq.head] = rdq.buf[rdq.head], zero
+		rdq.head = rdq.index(1)
+	}
+}
+
+// Clear移除所有元素。开启收缩策略时缓冲区恢复到最小容量，否则保留已分配的缓冲区。
+func (rdq *RingDeque[T]) Clear() {
+	if rdq.shrink && len(rdq.buf) > rdq.minCap {
+		rdq.buf = make([]T, rdq.minCap)
+	} else {
+		clear(rdq.buf) //!!! 清除对已移除元素的引用，以便垃圾回收
+	}
+	rdq.head = 0
+	rdq.size = 0
+}
+
+// reserve保证缓冲区至少还能再容纳n个元素，最多只重新分配一次
+func (rdq *RingDeque[T]) reserve(n int) {
+	if rdq.size+n > len(rdq.buf) {
+		rdq.resize(ceilPowerOfTwo(max(rdq.size+n, rdq.minCap)))
+	}
+}
+
+// PushFrontAll把items按原有顺序插入到队头，有元素被检查策略拒绝时抛出panic，队列保持不变。
+func (rdq *RingDeque[T]) PushFrontAll(items ...T) {
+	if checkValues(rdq.policy, items) != nil {
+		panic("空值不允许插入到队列")
+	}
+	rdq.reserve(len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		rdq.insertFront(items[i])
+	}
+}
+
+// PushBackAll把items按原有顺序追加到队尾，有元素被检查策略拒绝时抛出panic，队列保持不变。
+func (rdq *RingDeque[T]) PushBackAll(items ...T) {
+	if checkValues(rdq.policy, items) != nil {
+		panic("空值不允许插入到队列")
+	}
+	rdq.reserve(len(items))
+	for _, item := range items {
+		rdq.insertBack(item)
+	}
+}
+
+// Reverse把元素的顺序反转
+func (rdq *RingDeque[T]) Reverse() {
+	for i, j := 0, rdq.size-1; i < j; i, j = i+1, j-1 {
+		a, b := rdq.index(i), rdq.index(j)
+		rdq.buf[a], rdq.buf[b] = rdq.buf[b], rdq.buf[a]
+	}
+}
+
+// Clone返回一个独立的副本，副本的容量、收缩策略与检查策略都与原队列相同
+func (rdq *RingDeque[T]) Clone() Deque[T] {
+	c := *rdq
+	c.buf = slices.Clone(rdq.buf)
+	return &c
+}
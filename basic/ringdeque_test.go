@@ -0,0 +1,124 @@
+package basic
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestRingDeque(t *testing.T) {
+	var myDeque Deque[int] = &RingDeque[int]{}
+	myDeque.InsertFront(5)
+	myDeque.InsertBack(10)
+	myDeque.InsertFront(2)
+	myDeque.InsertBack(12) // 2 5 10 12
+	fmt.Println("myDeque.First() = ", myDeque.First())
+	fmt.Println("myDeque.Last() = ", myDeque.Last())
+	if myDeque.First() != 2 || myDeque.Last() != 12 {
+		t.Errorf("队头应为2，队尾应为12，实际为%d,%d", myDeque.First(), myDeque.Last())
+	}
+	var myQueue Queue[int] = &RingDeque[int]{}
+	for i := 0; i < 20; i++ {
+		myQueue.Insert(i)
+	}
+	for i := 0; i < 20; i++ {
+		if item := myQueue.Remove(); item != i {
+			t.Fatalf("第%d个移除的元素应为%d，实际为%d", i, i, item)
+		}
+	}
+}
+
+// !!! 以随机的两端插入、移除操作对比RingDeque与以切片模拟的双端队列的结果，覆盖缓冲区回绕（wrap around）与扩容的情况
+func TestRingDequeRandomOps(t *testing.T) {
+	rdq := NewRingDeque[int]()
+	var model []int
+	for step := 0; step < 100_000; step++ {
+		switch op := rand.IntN(4); {
+		case op == 0:
+			rdq.InsertFront(step)
+			model = slices.Insert(model, 0, step)
+		case op == 1:
+			rdq.InsertBack(step)
+			model = append(model, step)
+		case op == 2 && len(model) > 0:
+			if got := rdq.RemoveFirst(); got != model[0] {
+				t.Fatalf("第%d步RemoveFirst应为%d，实际为%d", step, model[0], got)
+			}
+			model = model[1:]
+		case op == 3 && len(model) > 0:
+			if got := rdq.RemoveLast(); got != model[len(model)-1] {
+				t.Fatalf("第%d步RemoveLast应为%d，实际为%d", step, model[len(model)-1], got)
+			}
+			model = model[:len(model)-1]
+		}
+		if rdq.Size() != len(model) {
+			t.Fatalf("第%d步元素个数应为%d，实际为%d", step, len(model), rdq.Size())
+		}
+	}
+	var items []int
+	for it := rdq.Iterator(); it.HasNext(); {
+		items = append(items, it.Next())
+	}
+	if !slices.Equal(items, model) {
+		t.Errorf("迭代器的结果与模型不一致")
+	}
+}
+
+func TestRingDequeShrink(t *testing.T) {
+	rdq := NewRingDeque[int](WithShrink())
+	for i := 0; i < 1024; i++ {
+		rdq.InsertBack(i)
+	}
+	if rdq.Cap() != 1024 {
+		t.Errorf("容量应为1024，实际为%d", rdq.Cap())
+	}
+	for i := 0; i < 1020; i++ {
+		rdq.RemoveFirst()
+	}
+	if rdq.Cap() > 16 {
+		t.Errorf("开启收缩策略后容量应收缩到16以下，实际为%d", rdq.Cap())
+	}
+	if rdq.First() != 1020 || rdq.Last() != 1023 {
+		t.Errorf("收缩后元素应为1020~1023，实际为%d~%d", rdq.First(), rdq.Last())
+	}
+
+	noShrink := NewRingDeque[int](WithCapacity(100))
+	if noShrink.Cap() != 128 {
+		t.Errorf("初始容量应向上取整为128，实际为%d", noShrink.Cap())
+	}
+	for i := 0; i < 1024; i++ {
+		noShrink.InsertFront(i)
+	}
+	for !noShrink.IsEmpty() {
+		noShrink.RemoveLast()
+	}
+	if noShrink.Cap() != 1024 {
+		t.Errorf("未开启收缩策略时容量应保持为1024，实际为%d", noShrink.Cap())
+	}
+	if _, ok := noShrink.TryRemoveFirst(); ok {
+		t.Errorf("空队列TryRemoveFirst应返回false")
+	}
+}
+
+// !!! 缓冲区已满与未满时Rotate走不同的路径，两种情况下的结果都应与切片的循环移动一致
+func TestRingDequeRotate(t *testing.T) {
+	for _, n := range []int{8, 7} { //!!! 最小容量为8，8个元素时缓冲区已满
+		for k := -10; k <= 10; k++ {
+			rdq := NewRingDeque[int]()
+			rdq.InsertBack(100) //!!! 先插入再移除，使head不在缓冲区开头
+			rdq.RemoveFirst()
+			model := make([]int, n)
+			for i := range model {
+				model[i] = i
+				rdq.InsertBack(i)
+			}
+			rdq.Rotate(k)
+			r := (k%n + n) % n
+			want := append(slices.Clone(model[n-r:]), model[:n-r]...)
+			if got := slices.Collect(rdq.All()); !slices.Equal(got, want) {
+				t.Errorf("n=%d, Rotate(%d) = %v，应为%v", n, k, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// selection包提供了泛型的选择算法：在不完全排序的情况下找出第k小的元素（k阶统计量）、最大或最小的k个元素、中位数和分位数。
+// !!! basic包的selection_test.go中的quickSelection只能处理[]int，并且总是以最后一个元素为基元，
+// !!! 对已排序的输入每次只能排除一个元素，复杂度退化为O(n²)。本包的选择算法：
+// !!! QuickSelect随机选取基元，期望复杂度为O(n)；MedianOfMedians（BFPRT算法）以“中位数的中位数”为基元，
+// !!! 最坏情况下也是O(n)，但常数因子较大；NthElement先做随机化的快速选择，迭代次数过多时改用BFPRT，兼顾二者的优点。
+// !!! 与sorting包一样，每个函数都有用于cmp.Ordered类型的X和使用cmp函数的XFunc两种形式。
+// !!! 本包中的序号k都从0开始，即k=0是最小的元素。
+package selection
+
+import (
+	"cmp"
+	"math/bits"
+	"math/rand/v2"
+
+	"datastructure/basic/sorting"
+)
+
+// smallCutoff是选择算法改用插入排序的规模
+const smallCutoff = 16
+
+func checkIndex(n, k int) {
+	if k < 0 || k >= n {
+		panic("序号超出范围")
+	}
+}
+
+// QuickSelect返回s中第k小（从0开始）的元素，并像NthElement一样重新排列s。
+// !!! 每次随机选取基元做三路划分，只需在包含第k个位置的一侧继续选择，期望复杂度为O(n)。
+// !!! 随机的基元使得任何固定的输入（包括已排序的输入）都不是最坏情况，但最坏情况仍是O(n²)（概率极小）。
+func QuickSelect[S ~[]E, E cmp.Ordered](s S, k int) E {
+	return QuickSelectFunc(s, k, cmp.Compare[E])
+}
+
+// QuickSelectFunc与QuickSelect相同，但使用cmp函数比较元素
+func QuickSelectFunc[S ~[]E, E any](s S, k int, cmp func(a, b E) int) E {
+	checkIndex(len(s), k)
+	quickSelect(s, k, -1, cmp)
+	return s[k]
+}
+
+// quickSelect对s做随机化的快速选择，划分次数达到maxRounds时改用BFPRT处理剩余的部分，maxRounds小于0时不限制划分的次数。
+func quickSelect[S ~[]E, E any](s S, k, maxRounds int, cmp func(a, b E) int) {
+	for rounds := 0; len(s) > smallCutoff; rounds++ {
+		if rounds == maxRounds {
+			bfprt(s, k, cmp)
+			return
+		}
+		p := rand.IntN(len(s))
+		s[0], s[p] = s[p], s[0] //!!! 把基元移到s[0]
+		lt, gt := sorting.Partition3Func(s, cmp)
+		switch {
+		case k < lt:
+			s = s[:lt]
+		case k >= gt:
+			s, k = s[gt:], k-gt
+		default:
+			return //!!! s[lt:gt]都等于基元，第k个位置已经就位
+		}
+	}
+	sorting.InsertionSortFunc(s, cmp)
+}
+
+// MedianOfMedians返回s中第k小（从0开始）的元素，并像NthElement一样重新排列s，最坏情况下的复杂度也是O(n)。
+// !!! 这就是BFPRT算法（以Blum、Floyd、Pratt、Rivest、Tarjan五位作者命名）：
+// !!! 把元素每5个分为一组，找出每组的中位数，再递归地找出这些中位数的中位数作为基元。
+// !!! 至少有一半的组的中位数不小于基元，这些组中至少各有3个元素不小于基元，因此至少有3n/10个元素不小于基元，
+// !!! 同理至少有3n/10个元素不大于基元，划分之后最多剩下7n/10个元素，
+// !!! 于是T(n) <= T(n/5) + T(7n/10) + O(n)，由于1/5 + 7/10 < 1，T(n) = O(n)。
+func MedianOfMedians[S ~[]E, E cmp.Ordered](s S, k int) E {
+	return MedianOfMediansFunc(s, k, cmp.Compare[E])
+}
+
+// MedianOfMediansFunc与MedianOfMedians相同，但使用cmp函数比较元素
+func MedianOfMediansFunc[S ~[]E, E any](s S, k int, cmp func(a, b E) int) E {
+	checkIndex(len(s), k)
+	bfprt(s, k, cmp)
+	return s[k]
+}
+
+func bfprt[S ~[]E, E any](s S, k int, cmp func(a, b E) int) {
+	for len(s) > smallCutoff {
+		p := medianOfMedians(s, cmp)
+		s[0], s[p] = s[p], s[0]
+		lt, gt := sorting.Partition3Func(s, cmp)
+		switch {
+		case k < lt:
+			s = s[:lt]
+		case k >= gt:
+			s, k = s[gt:], k-gt
+		default:
+			return
+		}
+	}
+	sorting.InsertionSortFunc(s, cmp)
+}
+
+// medianOfMedians返回“中位数的中位数”的位置：每组5个元素的中位数被依次移到s的开头，再递归地选出它们的中位数。
+// !!! 第i组的中位数移到s[i]，而i不超过这一组的起始位置，因此移动中位数不会打乱尚未处理的组。
+func medianOfMedians[S ~[]E, E any](s S, cmp func(a, b E) int) int {
+	m := 0
+	for i := 0; i < len(s); i += 5 {
+		group := s[i:min(i+5, len(s))]
+		sorting.InsertionSortFunc(group, cmp)
+		mid := i + (len(group)-1)/2
+		s[m], s[mid] = s[mid], s[m]
+		m++
+	}
+	bfprt(s[:m], (m-1)/2, cmp)
+	return (m - 1) / 2
+}
+
+// NthElement重新排列s，使得s[k]是s排序之后位于序号k处的元素，s[:k]中的元素都不大于s[k]，s[k+1:]中的元素都不小于s[k]，
+// 与C++的std::nth_element相同。两侧的元素之间没有顺序。
+// !!! 这是内省选择（introselect）：先做随机化的快速选择，划分次数超过2*log2(n)时说明基元总是很差，
+// !!! 改用最坏情况为O(n)的BFPRT算法，因此期望复杂度与QuickSelect相同，最坏情况下也是O(n)。
+func NthElement[S ~[]E, E cmp.Ordered](s S, k int) {
+	NthElementFunc(s, k, cmp.Compare[E])
+}
+
+// NthElementFunc与NthElement相同，但使用cmp函数比较元素
+func NthElementFunc[S ~[]E, E any](s S, k int, cmp func(a, b E) int) {
+	checkIndex(len(s), k)
+	quickSelect(s, k, 2*bits.Len(uint(len(s))), cmp)
+}
@@ -0,0 +1,205 @@
+package selection
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+var quickConfig = &quick.Config{MaxCount: 500}
+
+func checkProperty(t *testing.T, name string, f any) {
+	t.Helper()
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Errorf("%s: %v", name, err)
+	}
+}
+
+// isNthElement检查s是否满足NthElement的性质，并且是原来元素的一个排列
+func isNthElement[E cmp.Ordered](original, s []E, k int) bool {
+	sorted := slices.Clone(original)
+	slices.Sort(sorted)
+	if s[k] != sorted[k] {
+		return false
+	}
+	for i := range s {
+		if i < k && s[i] > s[k] || i > k && s[i] < s[k] {
+			return false
+		}
+	}
+	perm := slices.Clone(s)
+	slices.Sort(perm)
+	return slices.Equal(perm, sorted)
+}
+
+// selectsLikeSort检查sel选出的元素及其对s的重新排列是否正确，k由随机数决定
+func selectsLikeSort[E cmp.Ordered](sel func([]E, int) E) func([]E, uint) bool {
+	return func(s []E, r uint) bool {
+		if len(s) == 0 {
+			return true
+		}
+		k := int(r % uint(len(s)))
+		got := slices.Clone(s)
+		x := sel(got, k)
+		return x == got[k] && isNthElement(s, got, k)
+	}
+}
+
+func TestSelectProperties(t *testing.T) {
+	checkProperty(t, "QuickSelect", selectsLikeSort(QuickSelect[[]int]))
+	checkProperty(t, "QuickSelect(int8)", selectsLikeSort(QuickSelect[[]int8])) //!!! 大量重复元素
+	checkProperty(t, "MedianOfMedians", selectsLikeSort(MedianOfMedians[[]int]))
+	checkProperty(t, "MedianOfMedians(int8)", selectsLikeSort(MedianOfMedians[[]int8]))
+	checkProperty(t, "NthElement", selectsLikeSort(func(s []int, k int) int { NthElement(s, k); return s[k] }))
+	checkProperty(t, "NthElement(string)", selectsLikeSort(func(s []string, k int) string { NthElement(s, k); return s[k] }))
+	//!!! Func变体按照从大到小的顺序选择
+	checkProperty(t, "NthElementFunc", func(s []string, r uint) bool {
+		if len(s) == 0 {
+			return true
+		}
+		k := int(r % uint(len(s)))
+		got, want := slices.Clone(s), slices.Clone(s)
+		descending := func(a, b string) int { return strings.Compare(b, a) }
+		NthElementFunc(got, k, descending)
+		slices.SortFunc(want, descending)
+		return got[k] == want[k] &&
+			QuickSelectFunc(slices.Clone(s), k, descending) == want[k] &&
+			MedianOfMediansFunc(slices.Clone(s), k, descending) == want[k]
+	})
+}
+
+func TestSelectLarge(t *testing.T) {
+	inputs := map[string]func(n int) []int{
+		"random":   func(n int) []int { s := make([]int, n); fill(s, func(int) int { return rand.Int() }); return s },
+		"sorted":   func(n int) []int { s := make([]int, n); fill(s, func(i int) int { return i }); return s },
+		"reversed": func(n int) []int { s := make([]int, n); fill(s, func(i int) int { return n - i }); return s },
+		"allEqual": func(n int) []int { return make([]int, n) },
+		"organPipe": func(n int) []int {
+			s := make([]int, n)
+			fill(s, func(i int) int { return min(i, n-i) })
+			return s
+		},
+	}
+	const n = 100000
+	for name, gen := range inputs {
+		for _, k := range []int{0, 1, n / 2, n - 2, n - 1} {
+			s := gen(n)
+			for _, sel := range []func([]int, int) int{QuickSelect[[]int], MedianOfMedians[[]int]} {
+				got := slices.Clone(s)
+				sel(got, k)
+				if !isNthElement(s, got, k) {
+					t.Errorf("%s, k=%d: 选择的结果不正确", name, k)
+				}
+			}
+			got := slices.Clone(s)
+			NthElement(got, k)
+			if !isNthElement(s, got, k) {
+				t.Errorf("NthElement(%s, k=%d)的结果不正确", name, k)
+			}
+		}
+	}
+}
+
+func fill(s []int, f func(i int) int) {
+	for i := range s {
+		s[i] = f(i)
+	}
+}
+
+// countComparisons返回sel选择第k小元素时的比较次数
+func countComparisons(s []int, k int, sel func([]int, int, func(a, b int) int)) int {
+	count := 0
+	sel(s, k, func(a, b int) int {
+		count++
+		return cmp.Compare(a, b)
+	})
+	return count
+}
+
+// 已排序的输入会使quickSelection退化为O(n²)，而这里的选择算法的比较次数都是O(n)
+func TestSelectIsLinear(t *testing.T) {
+	const n = 1 << 16
+	sorted := make([]int, n)
+	fill(sorted, func(i int) int { return i })
+	sels := map[string]func([]int, int, func(a, b int) int){
+		"QuickSelectFunc":     func(s []int, k int, c func(a, b int) int) { QuickSelectFunc(s, k, c) },
+		"MedianOfMediansFunc": func(s []int, k int, c func(a, b int) int) { MedianOfMediansFunc(s, k, c) },
+		"NthElementFunc":      NthElementFunc[[]int],
+	}
+	for name, sel := range sels {
+		for _, k := range []int{0, n / 2, n - 1} {
+			//!!! BFPRT的比较次数上限约为22n，随机化的快速选择的期望约为3.4n
+			if got, limit := countComparisons(slices.Clone(sorted), k, sel), 30*n; got > limit {
+				t.Errorf("%s(k=%d): 比较次数%d超过了上限%d", name, k, got, limit)
+			}
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	checkProperty(t, "TopK", func(s []int, r uint8) bool {
+		k := int(r) % (len(s) + 3) //!!! k可能大于len(s)
+		want := slices.Clone(s)
+		slices.Sort(want)
+		slices.Reverse(want)
+		return slices.Equal(TopK(s, k), want[:min(k, len(s))])
+	})
+	checkProperty(t, "BottomK", func(s []int8, r uint8) bool {
+		k := int(r) % (len(s) + 3)
+		want := slices.Clone(s)
+		slices.Sort(want)
+		return slices.Equal(BottomK(s, k), want[:min(k, len(s))])
+	})
+	checkProperty(t, "TopKFunc", func(s []string, r uint8) bool {
+		k := int(r) % (len(s) + 1)
+		byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+		got := TopKFunc(s, k, byLen)
+		want := slices.Clone(s)
+		slices.SortFunc(want, byLen)
+		slices.Reverse(want)
+		//!!! 长度相同的字符串之间没有确定的顺序，只比较长度
+		return slices.EqualFunc(got, want[:k], func(a, b string) bool { return len(a) == len(b) })
+	})
+	//!!! 输入不会被修改
+	s := []int{5, 1, 4, 2, 3}
+	if got := TopK(s, 2); !slices.Equal(got, []int{5, 4}) || !slices.Equal(s, []int{5, 1, 4, 2, 3}) {
+		t.Errorf("TopK(s, 2) = %v, s = %v", got, s)
+	}
+}
+
+func TestMedianAndQuantile(t *testing.T) {
+	s := []int{7, 1, 3, 9, 5, 2, 8, 4, 6, 10}
+	if got := Median(s); got != 5 {
+		t.Errorf("Median = %d，应为5", got)
+	}
+	if got := Median(s[:9]); got != 5 {
+		t.Errorf("Median = %d，应为5", got)
+	}
+	for _, tc := range []struct {
+		q    float64
+		want int
+	}{{0, 1}, {0.1, 1}, {0.11, 2}, {0.5, 5}, {0.9, 9}, {0.99, 10}, {1, 10}} {
+		if got := Quantile(s, tc.q); got != tc.want {
+			t.Errorf("Quantile(%v) = %d，应为%d", tc.q, got, tc.want)
+		}
+	}
+	if !slices.Equal(s, []int{7, 1, 3, 9, 5, 2, 8, 4, 6, 10}) {
+		t.Errorf("Median和Quantile不应修改输入")
+	}
+	if got := MedianFunc([]string{"bb", "a", "ccc"}, func(a, b string) int { return cmp.Compare(len(a), len(b)) }); got != "bb" {
+		t.Errorf("MedianFunc = %q，应为\"bb\"", got)
+	}
+	for _, q := range []float64{-0.1, 1.1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Quantile(%v)应抛出panic", q)
+				}
+			}()
+			Quantile(s, q)
+		}()
+	}
+}
@@ -0,0 +1,103 @@
+package selection
+
+import (
+	"cmp"
+	"slices"
+
+	"datastructure/basic/myheap"
+)
+
+// TopK返回s中最大的k个元素，按从大到小的顺序排列，k大于len(s)时返回全部元素，s本身不会被修改。
+// !!! 用一个容量为k的有界最小堆保存当前最大的k个元素，堆顶是其中最小的一个：
+// !!! 新元素大于堆顶时替换堆顶并下沉，否则直接丢弃。复杂度为O(n log k)，额外空间为O(k)，
+// !!! 与NthElement相比，它不修改输入，也适合k远小于n、或者输入是逐个到来的情形。
+func TopK[S ~[]E, E cmp.Ordered](s S, k int) S {
+	return TopKFunc(s, k, cmp.Compare[E])
+}
+
+// TopKFunc与TopK相同，但使用cmp函数比较元素
+func TopKFunc[S ~[]E, E any](s S, k int, cmp func(a, b E) int) S {
+	return boundedSelect(s, k, func(a, b E) bool { return cmp(a, b) < 0 })
+}
+
+// BottomK返回s中最小的k个元素，按从小到大的顺序排列，k大于len(s)时返回全部元素，s本身不会被修改。
+func BottomK[S ~[]E, E cmp.Ordered](s S, k int) S {
+	return BottomKFunc(s, k, cmp.Compare[E])
+}
+
+// BottomKFunc与BottomK相同，但使用cmp函数比较元素
+func BottomKFunc[S ~[]E, E any](s S, k int, cmp func(a, b E) int) S {
+	return boundedSelect(s, k, func(a, b E) bool { return cmp(a, b) > 0 })
+}
+
+// boundedSelect返回s中按照less排在最后的k个元素，结果按照less的逆序排列：
+// 堆顶是已保留的元素中最先被less排出的一个，即下一个被替换的候选者。
+func boundedSelect[S ~[]E, E any](s S, k int, less func(a, b E) bool) S {
+	if k < 0 {
+		panic("k不能小于0")
+	}
+	k = min(k, len(s))
+	if k == 0 {
+		return S{}
+	}
+	h := myheap.NewFromSlice(slices.Clone(s[:k]), less)
+	for _, x := range s[k:] {
+		if less(h.Peek(), x) {
+			h.Set(0, x)
+		}
+	}
+	result := make(S, k)
+	for i := k - 1; i >= 0; i-- {
+		result[i] = h.Pop()
+	}
+	return result
+}
+
+// Median返回s的中位数，元素个数为偶数时返回位于中间的两个元素中较小的一个（下中位数），s本身不会被修改。
+// 复杂度为O(n)，s为空时抛出panic。
+func Median[S ~[]E, E cmp.Ordered](s S) E {
+	return MedianFunc(s, cmp.Compare[E])
+}
+
+// MedianFunc与Median相同，但使用cmp函数比较元素
+func MedianFunc[S ~[]E, E any](s S, cmp func(a, b E) int) E {
+	if len(s) == 0 {
+		panic("无法计算空切片的中位数")
+	}
+	return nth(s, (len(s)-1)/2, cmp)
+}
+
+// Quantile返回s的q分位数（0 <= q <= 1），s本身不会被修改，复杂度为O(n)。
+// !!! 这里采用“最近秩（nearest-rank）”的定义：排序后第ceil(q*n)个元素（q=0时是最小元素），
+// !!! 结果总是s中的某个元素，因此适用于任何有序的类型，而不需要在两个元素之间插值。
+// !!! 例如q=0.5时是下中位数，q=0.99时是P99。s为空或q不在[0, 1]之间时抛出panic。
+func Quantile[S ~[]E, E cmp.Ordered](s S, q float64) E {
+	return QuantileFunc(s, q, cmp.Compare[E])
+}
+
+// QuantileFunc与Quantile相同，但使用cmp函数比较元素
+func QuantileFunc[S ~[]E, E any](s S, q float64, cmp func(a, b E) int) E {
+	if len(s) == 0 {
+		panic("无法计算空切片的分位数")
+	}
+	if !(q >= 0 && q <= 1) { //!!! 同时排除了NaN
+		panic("分位数必须在0到1之间")
+	}
+	return nth(s, quantileIndex(len(s), q), cmp)
+}
+
+// quantileIndex返回n个元素的q分位数在排序后的序号（从0开始）
+func quantileIndex(n int, q float64) int {
+	rank := int(q * float64(n))
+	if float64(rank) < q*float64(n) { //!!! 向上取整
+		rank++
+	}
+	return max(rank-1, 0)
+}
+
+// nth在s的拷贝中选出第k小的元素
+func nth[S ~[]E, E any](s S, k int, cmp func(a, b E) int) E {
+	c := slices.Clone(s)
+	NthElementFunc(c, k, cmp)
+	return c[k]
+}
@@ -14,6 +14,8 @@ package basic
 import (
 	"fmt"
 	"testing"
+
+	"datastructure/basic/sorting"
 )
 
 // /////////////////////////////下面是一些特殊值的选择，最小值、最大值/////////////////////////////
@@ -171,7 +173,7 @@ func partialSortSelection(s []int, k int) []int {
 		}
 	}
 	s1 := s[0:k]
-	quickSort(s1) //!!!对前k个元素进行快速排序
+	sorting.QuickSort(s1) //!!!对前k个元素进行快速排序
 	s2 := s[k:]
 	insertAndSort(s1, s2)
 	return s1
@@ -304,7 +306,8 @@ func TestPartialSortSelection2(t *testing.T) {
 	fmt.Println(s)
 }
 
-// !!!
+// !!! quickSelection总是以最后一个元素为基元，对已排序的输入复杂度退化为O(n²)，
+// !!! 泛型的、随机化的快速选择以及最坏情况为O(n)的BFPRT算法见selection包。
 func quickSelection(s []int, k int) int {
 	l := len(s)
 	baseIndex := l - 1                             //!!! 选取数组的最后一个元素基元
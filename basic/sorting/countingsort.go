@@ -0,0 +1,56 @@
+package sorting
+
+// Integer是所有整数类型的约束
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// CountingSort（计数排序）算法是一种不基于比较的线性排序方法，s中的元素必须在[min, max]范围之内。
+// !!! 工作原理是按照被排序元素的取值范围创建一个新的，
+// !!! 所有元素初始值都为0的整数数组，该整数数组的“序号”与被排序元素取值范围中的
+// !!! 每个可能元素建立进行一对一映射，这样计数素组的序号的大小既能映射会“源数值”的大小，也表明了其顺序。
+// !!! 这个计数数组称为（可能出现元素的）计数数组。这样，遍历被排序的元素，然后找到该元素所映射的计数数组序号，
+// !!!  使该序号下的数组元素值加1。这样，在输出的时候，按照计数数组的顺序，
+// !!! 输出计数不为0（计数为0表示该元素没有出现过）的技术数组元素序号所映射的排序元素。
+// !!! 计数排序适合有对有固定取值范围的元素进行排序，尤其是取值范围不大（内存开销不大），
+// !!! 且元素重复出现次数较多的排序场景。
+func CountingSort[S ~[]E, E Integer](s S, min, max E) {
+	//!!! 根据被排序元素的取值范围制作计数素组。
+	//!!! 序号的计算都在uint64上进行，对有符号整数来说，补码的减法保证了max-min、e-min在溢出时仍然正确
+	countings := make([]int, uint64(max)-uint64(min)+1)
+	//!!! 遍历被排序元素，按照被排序元素值与计数数组序号的映射关系增加被排序元素的出现次数
+	for _, e := range s {
+		countings[uint64(e)-uint64(min)] += 1 //!!! 被排序的元素e，映射为计数数组元素的序号为 e-min
+	}
+	var i = 0
+	//根据出现的元素的计数，回写排序的结果
+	for j, c := range countings { //j+min映射为s[i]的值,c代表s[i]的值出现的次数
+		for ; c > 0; c-- {
+			s[i] = E(uint64(min) + uint64(j)) //j+min映射为S[i]的值
+			i += 1
+		}
+	}
+}
+
+// CountingSortFunc是按照key函数对任意类型的元素进行的计数排序，key(e)必须在[min, max]范围之内。
+// !!! 元素本身无法像整数那样由计数数组的序号“还原”出来，因此先把计数转换为每个key在结果中的起始位置（前缀和），
+// !!! 再把元素依次放到各自的位置上，这需要一个与s等长的辅助切片。key相同的元素保持原来的相对顺序，即排序是稳定的。
+func CountingSortFunc[S ~[]E, E any](s S, key func(E) int, min, max int) {
+	countings := make([]int, max-min+1)
+	for _, e := range s {
+		countings[key(e)-min] += 1
+	}
+	//!!! 把计数转换为起始位置
+	start := 0
+	for j, c := range countings {
+		countings[j] = start
+		start += c
+	}
+	sorted := make(S, len(s))
+	for _, e := range s {
+		k := key(e) - min
+		sorted[countings[k]] = e
+		countings[k] += 1
+	}
+	copy(s, sorted)
+}
@@ -0,0 +1,12 @@
+/*
+Package sorting提供了常见排序算法的泛型实现，这些算法最初以只能处理[]int的形式写在basic包的sort_test.go中。
+每种算法都有两种形式：X(s)用于元素类型满足cmp.Ordered的切片，按照cmp.Compare的顺序（即从小到大）排序；
+XFunc(s, cmp)用于任意元素类型的切片，cmp(a, b)在a<b时返回负数，a>b时返回正数，a与b相等时返回0，
+与slices.SortFunc的约定相同。
+*/
+package sorting
+
+// swap函数对给定数组中的两个元素位置进行元素的交换操作
+func swap[S ~[]E, E any](s S, i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
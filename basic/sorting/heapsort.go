@@ -0,0 +1,46 @@
+package sorting
+
+import "cmp"
+
+// HeapSort（堆排序）是一种就地排序算法，主要是把数组看作了完整二叉树，利用完整二叉树的性质进行排序，
+// 有关完整二叉树的知识详见 file://../myheap/数据结构学习-二叉树.pdf
+// !!! basic包中最初的heapSort每一轮都从最后一棵最小子树开始重新“堆化”整个数组，只为了把最小元素交换到位置0，
+// !!! 然后对s[1:]递归，每一轮都是O(n)，总的复杂度是O(n²)，而且只比较了子树的顶点与其子节点，并不能真正建立堆。
+// !!! 正确的做法是只建立一次堆（复杂度为O(n)），而且建立的是“最大堆”：
+// !!! 把堆顶（最大的元素）与堆的最后一个元素交换，堆的规模减1，再对新的堆顶做下沉（siftDown）处理，
+// !!! 每一轮是O(log n)，总的复杂度是O(n log n)，排序的结果在数组中从小到大排列。
+func HeapSort[S ~[]E, E cmp.Ordered](s S) {
+	HeapSortFunc(s, cmp.Compare[E])
+}
+
+// HeapSortFunc与HeapSort相同，但使用cmp函数比较元素
+func HeapSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	n := len(s)
+	//!!! n/2 - 1是最后一棵最小子树的顶点，从它开始向前对每棵子树的顶点做下沉处理，建立最大堆
+	for i := n/2 - 1; i >= 0; i-- {
+		siftDown(s, i, n, cmp)
+	}
+	//!!! 反复把堆顶的最大元素交换到堆的末尾，然后缩小堆的规模
+	for end := n - 1; end > 0; end-- {
+		swap(s, 0, end)
+		siftDown(s, 0, end, cmp)
+	}
+}
+
+// siftDown使位于i处的元素在规模为n的最大堆中下沉，与myheap包的down相同，只是比较的方向相反
+func siftDown[S ~[]E, E any](s S, i, n int, cmp func(a, b E) int) {
+	for {
+		child := 2*i + 1             //!!! 位置为i的节点的左子树节点位于2*i+1处
+		if child >= n || child < 0 { // child < 0 after int overflow
+			return
+		}
+		if right := child + 1; right < n && cmp(s[right], s[child]) > 0 {
+			child = right
+		}
+		if cmp(s[child], s[i]) <= 0 {
+			return
+		}
+		swap(s, i, child)
+		i = child
+	}
+}
@@ -0,0 +1,64 @@
+package sorting
+
+import "cmp"
+
+// InsertionSort（插入排序）就像对你手中的扑克牌进行排序。
+// !!! 您将卡片分为两组：已排序的卡片和未排序的卡片。然后，从未排序的组中挑选一张卡，放在已排序的组中的最后位置，
+// !!! 此时，从排序组的最后一个牌（待定位置的牌）作为当前处理的牌，与前一张牌比较，
+// !!! 如果小于前一张牌，那么二者就交换顺序。再将当前牌的位置减1（前移，这样，当前牌还是待定位置的牌)，
+// !!! 重复与前一张牌比较，直到当前的牌不再小于前面的牌为止。
+func InsertionSort[S ~[]E, E cmp.Ordered](s S) {
+	InsertionSortFunc(s, cmp.Compare[E])
+}
+
+// InsertionSortFunc与InsertionSort相同，但使用cmp函数比较元素
+func InsertionSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	l := len(s)
+	if l <= 1 {
+		return
+	}
+	//!!! 初始情况下认为s[0]是已经排好的，故而从i=1处开始对未排序的元素进行插入排序处理。
+	//!!! i表示当前处理的尚未排序的元素位置，
+	for i := 1; i < l; i++ {
+		//!!!由于s[0:i]已经排好序，从后向前处理，只要当前的元素比前一个元素小，二者就交换顺序
+		for j := i; j > 0 && cmp(s[j], s[j-1]) < 0; j-- {
+			swap(s, j, j-1)
+		}
+	}
+}
+
+// insertionSort2的原理是总是将“未排序的元素”插入到“已排好序”的集合中。
+// !!!该算法原理在现实生活中就是一边摸扑克牌，一边排序的算法。
+// !!! 这是一种不太好的方式，增加了移动操作步骤。
+func insertionSort2[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	l := len(s)
+	if l <= 1 {
+		return
+	}
+	//!!! 初始情况下认为s[0]是已经排好的，故而从i=1处开始对未排序的元素进行插入排序处理。
+	//!!! i表示当前处理的尚未排序的元素位置，
+	for i := 1; i < l; i++ {
+		unSortNum := s[i] //unSortNum表示未排序的元素
+		sub := s[0 : i+1] //
+		for j := 0; j < i; j++ {
+			if cmp(unSortNum, s[j]) < 0 {
+				moveBackOneElmentThenInsert(sub, j, unSortNum)
+				break
+			}
+		}
+	}
+}
+
+// moveBackOneElmentThenInsert将给定的切片s中位于位置的i元素依次向后
+// !!!  移动一位，挤掉最后一个元素,并将位置i处的元素替换为d.
+// !!! 该方法服务于插入排序法insertionSort2
+func moveBackOneElmentThenInsert[S ~[]E, E any](s S, i int, d E) {
+	l := len(s)
+	if l <= 0 || i >= l {
+		return
+	}
+	for j := l - 1; j > i; j-- {
+		s[j] = s[j-1]
+	}
+	s[i] = d
+}
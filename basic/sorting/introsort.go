@@ -0,0 +1,109 @@
+package sorting
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+const (
+	introInsertionCutoff = 12 // 元素个数不超过它的分区改用插入排序
+	introNintherCutoff   = 50 // 元素个数不少于它的分区用“九数取中（ninther）”选取基元，否则用“三数取中”
+)
+
+// IntroSort（内省排序）是以快速排序为主、结合了堆排序和插入排序的混合排序算法，最坏情况下的复杂度也是O(n log n)。
+// !!! QuickSort总是选取最后一个元素作为基元，对已排好序的输入每次只能分出一个元素，复杂度退化为O(n²)，递归深度也是O(n)。
+// !!! IntroSort做了以下改进：
+// !!! 1. 用“三数取中（median-of-three）”或“九数取中（ninther，即三组三数取中的中位数）”选取基元，已排序、逆序等输入不再是最坏情况；
+// !!! 2. 记录递归深度，超过2*log2(n)层时说明基元选取总是很差（例如遇到了专门构造的对抗性输入），改用堆排序处理这个分区；
+// !!! 3. 元素个数很少的分区改用插入排序，它在小规模数据上比快速排序更快；
+// !!! 4. 用“三路划分（three-way partitioning）”把分区分为小于、等于、大于基元的三部分，等于基元的元素不再参与递归，
+// !!!    因此大量重复元素的输入只需O(n)到O(n log k)的时间（k为不同元素的个数）；
+// !!! 5. 只对较小的一侧递归，较大的一侧在循环中继续处理，保证额外的栈空间是O(log n)。
+func IntroSort[S ~[]E, E cmp.Ordered](s S) {
+	IntroSortFunc(s, cmp.Compare[E])
+}
+
+// IntroSortFunc与IntroSort相同，但使用cmp函数比较元素
+func IntroSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	if len(s) < 2 {
+		return
+	}
+	introSort(s, 2*bits.Len(uint(len(s))), cmp)
+}
+
+func introSort[S ~[]E, E any](s S, depthLimit int, cmp func(a, b E) int) {
+	for len(s) > introInsertionCutoff {
+		if depthLimit == 0 { //!!! 递归过深，改用最坏情况也是O(n log n)的堆排序
+			HeapSortFunc(s, cmp)
+			return
+		}
+		depthLimit--
+		swap(s, 0, choosePivot(s, cmp)) //!!! 把基元移到分区的开头
+		lt, gt := Partition3Func(s, cmp)
+		//!!! 对较小的一侧递归，较大的一侧在循环中继续处理
+		if lt < len(s)-gt {
+			introSort(s[:lt], depthLimit, cmp)
+			s = s[gt:]
+		} else {
+			introSort(s[gt:], depthLimit, cmp)
+			s = s[:lt]
+		}
+	}
+	InsertionSortFunc(s, cmp)
+}
+
+// choosePivot返回基元的位置：较小的分区用三数取中，较大的分区用九数取中
+func choosePivot[S ~[]E, E any](s S, cmp func(a, b E) int) int {
+	n := len(s)
+	lo, mid, hi := 0, n/2, n-1
+	if n >= introNintherCutoff {
+		step := n / 8
+		lo = medianOfThree(s, lo, lo+step, lo+2*step, cmp)
+		mid = medianOfThree(s, mid-step, mid, mid+step, cmp)
+		hi = medianOfThree(s, hi-2*step, hi-step, hi, cmp)
+	}
+	return medianOfThree(s, lo, mid, hi, cmp)
+}
+
+// medianOfThree返回s[a]、s[b]、s[c]三者中位于中间的那个元素的位置
+func medianOfThree[S ~[]E, E any](s S, a, b, c int, cmp func(a, b E) int) int {
+	if cmp(s[b], s[a]) < 0 {
+		a, b = b, a
+	}
+	//!!! 此时s[a] <= s[b]
+	if cmp(s[c], s[b]) >= 0 {
+		return b
+	}
+	if cmp(s[c], s[a]) <= 0 {
+		return a
+	}
+	return c
+}
+
+// Partition3以s[0]为基元对s做三路划分（Dijkstra的“荷兰国旗”划分），
+// 返回的lt、gt满足：s[:lt]小于基元，s[lt:gt]等于基元，s[gt:]大于基元。s不能为空。
+// !!! 三路划分使得大量重复元素的输入不会退化：与基元相等的元素一次就全部就位。
+// !!! IntroSort、ParallelQuickSort以及selection包中的选择算法都使用这个划分。
+func Partition3[S ~[]E, E cmp.Ordered](s S) (lt, gt int) {
+	return Partition3Func(s, cmp.Compare[E])
+}
+
+// Partition3Func与Partition3相同，但使用cmp函数比较元素
+func Partition3Func[S ~[]E, E any](s S, cmp func(a, b E) int) (lt, gt int) {
+	pivot := s[0]
+	lt, gt = 0, len(s)
+	for i := 1; i < gt; {
+		switch c := cmp(s[i], pivot); {
+		case c < 0:
+			swap(s, lt, i)
+			lt++
+			i++
+		case c > 0:
+			gt--
+			swap(s, i, gt)
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
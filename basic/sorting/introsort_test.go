@@ -0,0 +1,175 @@
+package sorting
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+	"testing"
+)
+
+func TestIntroSortProperties(t *testing.T) {
+	checkProperty(t, "IntroSort", sortedLikeStdlib(IntroSort[[]int]))
+	checkProperty(t, "IntroSort(int8)", sortedLikeStdlib(IntroSort[[]int8])) //!!! 大量重复元素
+	checkProperty(t, "IntroSort(float64)", sortedLikeStdlib(IntroSort[[]float64]))
+	checkProperty(t, "IntroSortFunc", sortedLikeStdlibFunc(IntroSortFunc[[]string]))
+}
+
+// Partition3之后三段分别小于、等于、大于基元，并且元素的多重集合不变
+func TestPartition3(t *testing.T) {
+	checkProperty(t, "Partition3", func(s []int8) bool { //!!! int8有大量重复元素
+		if len(s) == 0 {
+			return true
+		}
+		pivot, orig := s[0], slices.Clone(s)
+		lt, gt := Partition3(s)
+		for i, x := range s {
+			if c := cmp.Compare(x, pivot); (i < lt && c >= 0) || (i >= lt && i < gt && c != 0) || (i >= gt && c <= 0) {
+				return false
+			}
+		}
+		slices.Sort(orig)
+		slices.Sort(s)
+		return lt < gt && slices.Equal(orig, s)
+	})
+}
+
+// countComparisons用sort对s排序，返回比较的次数，并检查结果是否有序
+func countComparisons(t *testing.T, s []int, sort func([]int, func(a, b int) int)) int {
+	t.Helper()
+	count := 0
+	sort(s, func(a, b int) int {
+		count++
+		return cmp.Compare(a, b)
+	})
+	if !slices.IsSorted(s) {
+		t.Fatalf("排序的结果不是有序的")
+	}
+	return count
+}
+
+// nLogN返回n*log2(n)，用作比较次数的上限的基准
+func nLogN(n int) int {
+	return n * bits.Len(uint(n))
+}
+
+// 以下是常见的“对抗性”输入，它们会使朴素的快速排序退化为O(n²)
+var adversarialInputs = map[string]func(n int) []int{
+	"sorted": func(n int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		return s
+	},
+	"reversed": func(n int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = n - i
+		}
+		return s
+	},
+	"allEqual": func(n int) []int {
+		return make([]int, n)
+	},
+	"fewDistinct": func(n int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i % 4
+		}
+		return s
+	},
+	"organPipe": func(n int) []int { //!!! 先升后降
+		s := make([]int, n)
+		for i := range s {
+			s[i] = min(i, n-i)
+		}
+		return s
+	},
+	"sawtooth": func(n int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i % 100
+		}
+		return s
+	},
+	"medianOf3Killer": medianOfThreeKiller,
+}
+
+// medianOfThreeKiller构造使“三数取中”快速排序退化的输入（Musser, 1997）
+func medianOfThreeKiller(n int) []int {
+	k := n / 2
+	s := make([]int, n)
+	for i := 1; i <= k; i++ {
+		if i%2 == 1 {
+			s[i-1] = i
+			s[i] = k + i
+		}
+		s[k+i-1] = 2 * i
+	}
+	return s
+}
+
+func TestIntroSortAdversarialInputs(t *testing.T) {
+	n := 1 << 14
+	for name, input := range adversarialInputs {
+		comparisons := countComparisons(t, input(n), IntroSortFunc[[]int])
+		if limit := 4 * nLogN(n); comparisons > limit {
+			t.Errorf("%s: 比较次数%d超过了4*n*log(n)=%d", name, comparisons, limit)
+		}
+	}
+}
+
+// antiQuicksort使用McIlroy的“antiqsort”对抗者（A Killer Adversary for Quicksort, 1999）对n个元素排序，返回比较的次数。
+// !!! 比较函数在排序过程中才决定元素的值：它总是让基元的候选者显得尽可能“小”，
+// !!! 可以使任何只依赖比较来选取基元的快速排序退化为O(n²)。
+func antiQuicksort(t *testing.T, n int, sort func([]int, func(a, b int) int)) int {
+	t.Helper()
+	gas := n //!!! 值尚未确定的元素（“气体”）的值，大于所有已确定的（“固体”）值
+	val := make([]int, n)
+	for i := range val {
+		val[i] = gas
+	}
+	nsolid, candidate := 0, 0
+	freeze := func(x int) {
+		val[x] = nsolid
+		nsolid++
+	}
+	items := make([]int, n) //!!! 被排序的是元素的序号，元素的值保存在val中
+	for i := range items {
+		items[i] = i
+	}
+	comparisons := 0
+	sort(items, func(x, y int) int {
+		comparisons++
+		if val[x] == gas && val[y] == gas {
+			if x == candidate {
+				freeze(x)
+			} else {
+				freeze(y)
+			}
+		}
+		if val[x] == gas {
+			candidate = x
+		} else if val[y] == gas {
+			candidate = y
+		}
+		return cmp.Compare(val[x], val[y])
+	})
+	if !slices.IsSortedFunc(items, func(x, y int) int { return cmp.Compare(val[x], val[y]) }) {
+		t.Fatalf("排序的结果不是有序的")
+	}
+	return comparisons
+}
+
+// IntroSort在递归过深时改用堆排序，因此即使面对antiqsort对抗者，比较次数仍是O(n log n)；
+// 作为对照，朴素的QuickSort会退化为O(n²)。
+func TestIntroSortAntiQuicksort(t *testing.T) {
+	n := 1 << 14
+	if comparisons, limit := antiQuicksort(t, n, IntroSortFunc[[]int]), 4*nLogN(n); comparisons > limit {
+		t.Errorf("IntroSort: 比较次数%d超过了4*n*log(n)=%d", comparisons, limit)
+	}
+	m := 2000
+	if comparisons := antiQuicksort(t, m, QuickSortFunc[[]int]); comparisons < m*m/8 {
+		t.Errorf("QuickSort: 比较次数%d应为O(n²)", comparisons)
+	}
+}
@@ -0,0 +1,128 @@
+package sorting
+
+import "cmp"
+
+// MergeSort（归并排序）的过程就是将数组分成两半，对每一半进行排序，然
+// !!! 将”已排序”的两半合并在一起，重复这个过程，直到整个数组排序完毕。当然，这种
+// !!! 不断二分的粒度可以根据具体数据规模加以控制，最小粒度的二分就相当于与每两个相邻
+// !!! 的元素作为一个排序小组，排序之后，在对已排序的两个部分逐层向上合并（含排序）。
+func MergeSort[S ~[]E, E cmp.Ordered](s S) {
+	MergeSortFunc(s, cmp.Compare[E])
+}
+
+// MergeSortFunc与MergeSort相同，但使用cmp函数比较元素
+func MergeSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	//切分排序。按照最小的粒度，将切片s的每两个相邻的元素进行排序。
+	divide(s, cmp)
+	//归并排序，从最小的粒度开始归并相邻的两组已排序的分组。
+	merge(s, cmp)
+}
+
+// MergeSort2以递归方式对给定数组s进行归并排序,递归是一种易于理解的分治策略实现方式。
+// !!! 分治策略要对问题进行分解，当子问题足够大，需要递归求解时，我们称为递归情况（recursivce case）。
+// !!!  当子问题变得足够小，不需要递归时，我们说递归已经”触底“，进入了基本情况（base case）
+// !!! granularity表示二分数组的粒度，数组在不断二分后，一旦规模小于给定的粒度后就不在进行二分，而是
+// !!! 进行真正的排序，sortInLocal则表示对最小粒度的数组进行排序时所使用的就地排序算法，例如SelectionSort[S]。
+// !!! granularity可以控制二分的嵌套层数，sortInLocal参数可以选择合适的就地排序算法。
+func MergeSort2[S ~[]E, E cmp.Ordered](s S, granularity int, sortInLocal func(S)) {
+	MergeSort2Func(s, granularity, sortInLocal, cmp.Compare[E])
+}
+
+// MergeSort2Func与MergeSort2相同，但使用cmp函数比较元素，sortInLocal也应使用同样的顺序排序
+func MergeSort2Func[S ~[]E, E any](s S, granularity int, sortInLocal func(S), cmp func(a, b E) int) {
+	l := len(s)
+	if l <= max(granularity, 1) { //!!!当子问题达到了”触底“的基本情况（base case）就不再递归，而执行对最小子问题的处理。
+		sortInLocal(s)
+		return
+	}
+	//问题分解，将数组二分为左右两个数组
+	left := s[:l/2]
+	right := s[l/2:]
+	//对左数组进行归并排序
+	MergeSort2Func(left, granularity, sortInLocal, cmp)
+	//对右侧数组进行归并排序
+	MergeSort2Func(right, granularity, sortInLocal, cmp)
+	//对已排好序的两侧数组进行归并排序
+	sortOrderedListsByInsertion(left, right, cmp)
+}
+
+// divide进行切分排序，将给定切片s相邻的两个元素进行分组，然后排序。
+// !!!! 最后输出的切片中，两两分组的组内元素都已排好序（每组只有两个元素，而且是相邻的两个元素）
+func divide[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	slen := len(s)
+	//相邻两个元素比较，排序后存储如结果数组。
+	for i := 0; i+1 < slen; i += 2 {
+		if cmp(s[i], s[i+1]) > 0 {
+			swap(s, i, i+1)
+		}
+	}
+}
+
+// merge对已完成切分排序的素组进行就地的归并排序。
+// !!! 思路就是以先以2个元素为分组单位，对每相邻的两个分组的进行合并排序，
+// !!! 然后再以2*2个元素为分组单位，对每相邻的两个分组进行合并排序，
+// !!! 如此，不断以2的n次方个元素来扩大分组的元素规模，直到将给定的切片分组为两个分组进行归并排序为止。
+func merge[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	slen := len(s)
+	//!!! 在循环中，将分组的初始规模设置为2，对该规模的分组完成归并后，就将分组的规模翻倍，继续进行归并
+	for groupSize := 2; groupSize < slen; groupSize *= 2 {
+		//!!! 按照分组规模不停地将每相邻的两个分组（左、右两个分组）进行归并排序，直至遍历完整个切片。
+		//!!! 当按照当前的分组规模划分左分组会超出切片边界时，右分组为空，无需归并。
+		for i := 0; i+groupSize < slen; i += groupSize * 2 {
+			left := s[i : i+groupSize]
+			right := s[i+groupSize : min(i+groupSize*2, slen)]
+			sortOrderedListsByInsertion(left, right, cmp)
+		}
+	}
+}
+
+// MergeSortedLists对已经排好序的左右两个分组进行合并排序，返回合并后的新切片。
+// !!! 思路就是将左右两个分组中的第一个元素，也就是各自分组最小的元素拿出来进行比较，
+// !!! 将最小的元素“移入（从源分组中删除）”结果切片中，这样，直到两个分组的元素都被取空，
+// !!! 就完成了两个数组的归并排序。
+// !!! 这个过程产生了新的列表，不是能用于就地排序，被sortOrderedListsByInsertion所取代
+func MergeSortedLists[S ~[]E, E cmp.Ordered](left, right S) S {
+	return MergeSortedListsFunc(left, right, cmp.Compare[E])
+}
+
+// MergeSortedListsFunc与MergeSortedLists相同，但使用cmp函数比较元素。两个分组中相等的元素，左侧分组的排在前面。
+func MergeSortedListsFunc[S ~[]E, E any](left, right S, cmp func(a, b E) int) S {
+	result := make(S, 0, len(left)+len(right))
+	//!!! 左右分组都没取空时，取出二者中较小的第一个元素
+	for len(left) > 0 && len(right) > 0 {
+		if cmp(left[0], right[0]) <= 0 {
+			result = append(result, left[0])
+			left = left[1:]
+		} else {
+			result = append(result, right[0])
+			right = right[1:]
+		}
+	}
+	//!!! 某一个分组被取空后，另一个分组剩余的元素都比结果中的元素大
+	result = append(result, left...)
+	return append(result, right...)
+}
+
+// sortOrderedListsByInsertion使用插入算法来将left和right两个有序数组的重新排序，使得左右两个数组整体上符合从左到右的排序顺序
+// !!! 这是一种就地处理方法，最后的结果是，left列表存储较小的元素，而right列表存储较大的元素，二者都是有序的
+func sortOrderedListsByInsertion[S ~[]E, E any](left, right S, cmp func(a, b E) int) {
+	ll := len(left)
+	lr := len(right)
+	if ll == 0 || lr == 0 {
+		return
+	}
+	//不断拿出两个数组中最大的元素进行比较，将较小的交换到左侧，较大的交换到右侧，
+	//这样，右侧列表靠近尾部的元素总是两个列表中最大的。（但是这两个元素中，小的一个元素不一定是次小的）
+	//然后以插入排序（在有序列表中找到合适位置插入元素）的思想对左侧的有序列表的元素中进行重新排序。
+	for i := lr - 1; i >= 0; i-- {
+		maxL := left[ll-1]
+		if cmp(right[i], maxL) < 0 { //找到两个列表中最大元素的放在右列表的末尾
+			left[ll-1] = right[i]
+			right[i] = maxL
+			//!!! 左侧列表只有最后一个元素变小了，把它前移到合适的位置
+			for j := ll - 1; j >= 1 && cmp(left[j], left[j-1]) < 0; j-- {
+				swap(left, j, j-1)
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package sorting
+
+import (
+	"cmp"
+	"math/bits"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// DefaultGrain是并行排序的默认粒度：元素个数不超过它的分区不再分派给新的goroutine，而是在当前goroutine中顺序排序
+const DefaultGrain = 1 << 14
+
+// !!! MergeSort2的granularity和sortInLocal已经体现了并行排序的结构：把切片递归地二分到一定的粒度，
+// !!! 粒度以下的分区交给一个顺序的排序算法，再逐层归并。本文件中的并行排序在此基础上：
+// !!! 1. 递归二分时把其中一半交给新的goroutine处理，当前goroutine处理另一半，然后等待二者完成；
+// !!! 2. 同时运行的goroutine个数受workers限制（见forker），分区不超过grain个元素时在当前goroutine中顺序排序；
+// !!! 3. 归并本身也是并行的（见parallelMerge），否则最顶层的一次归并就要顺序地处理全部n个元素，限制了加速比。
+// !!! grain小于等于0时使用DefaultGrain，workers小于等于0时使用runtime.GOMAXPROCS(0)。
+
+// ParallelSort使用GOMAXPROCS个工作goroutine和默认的粒度对s进行并行的稳定排序，等价于ParallelMergeSort(s, 0, 0)
+func ParallelSort[S ~[]E, E cmp.Ordered](s S) {
+	ParallelMergeSortFunc(s, 0, 0, cmp.Compare[E])
+}
+
+// ParallelSortFunc与ParallelSort相同，但使用cmp函数比较元素
+func ParallelSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	ParallelMergeSortFunc(s, 0, 0, cmp)
+}
+
+// forker限制同时运行的goroutine个数，相当于一个有界的工作池（worker pool）。
+// !!! 令牌的个数是workers-1，调用并行排序的goroutine本身占一个名额。fork2在取得令牌时把a交给新的goroutine，
+// !!! 取不到令牌时直接在当前goroutine中依次执行a和b，而不是阻塞等待，因此递归的fork2不会因为令牌耗尽而死锁。
+// !!! 等待子goroutine的父goroutine处于阻塞状态，并不占用处理器，因此同时在运行的goroutine不超过workers个。
+type forker struct {
+	tokens chan struct{}
+}
+
+func newForker(workers int) *forker {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &forker{tokens: make(chan struct{}, workers-1)}
+}
+
+// fork2执行a和b，在有空闲的名额时二者并行执行，返回时二者都已完成
+func (f *forker) fork2(a, b func()) {
+	select {
+	case f.tokens <- struct{}{}:
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-f.tokens }()
+			a()
+		}()
+		b()
+		wg.Wait()
+	default:
+		a()
+		b()
+	}
+}
+
+// ParallelMergeSort是并行的稳定归并排序，需要一个与s等长的辅助切片。
+// !!! 两半分别排序之后，结果在s和辅助切片之间交替存放（与BottomUpMergeSort相同），因此每一层只需归并一次，无需复制。
+// !!! 粒度以下的分区用TimSort排序，归并时相等的元素中左边的排在前面，因此整个排序是稳定的。
+func ParallelMergeSort[S ~[]E, E cmp.Ordered](s S, grain, workers int) {
+	ParallelMergeSortFunc(s, grain, workers, cmp.Compare[E])
+}
+
+// ParallelMergeSortFunc与ParallelMergeSort相同，但使用cmp函数比较元素
+func ParallelMergeSortFunc[S ~[]E, E any](s S, grain, workers int, cmp func(a, b E) int) {
+	if len(s) < 2 {
+		return
+	}
+	ps := newParallelSorter[S](grain, workers, cmp)
+	if len(s) <= ps.grain {
+		TimSortFunc(s, cmp)
+		return
+	}
+	ps.mergeSort(s, make(S, len(s)), false)
+}
+
+// parallelSorter保存并行排序的参数
+type parallelSorter[S ~[]E, E any] struct {
+	grain int
+	cmp   func(a, b E) int
+	*forker
+}
+
+func newParallelSorter[S ~[]E, E any](grain, workers int, cmp func(a, b E) int) *parallelSorter[S, E] {
+	if grain <= 0 {
+		grain = DefaultGrain
+	}
+	return &parallelSorter[S, E]{grain: grain, cmp: cmp, forker: newForker(workers)}
+}
+
+// mergeSort对s进行排序，buf是与s等长的辅助切片，toBuf为true时结果存放在buf中，否则存放在s中。
+func (ps *parallelSorter[S, E]) mergeSort(s, buf S, toBuf bool) {
+	if len(s) <= ps.grain {
+		TimSortFunc(s, ps.cmp)
+		if toBuf {
+			copy(buf, s)
+		}
+		return
+	}
+	mid := len(s) / 2
+	//!!! 两半的结果存放在与最终结果相对的另一个切片中，再归并到最终结果所在的切片
+	ps.fork2(
+		func() { ps.mergeSort(s[:mid], buf[:mid], !toBuf) },
+		func() { ps.mergeSort(s[mid:], buf[mid:], !toBuf) },
+	)
+	if toBuf {
+		ps.parallelMerge(buf, s[:mid], s[mid:])
+	} else {
+		ps.parallelMerge(s, buf[:mid], buf[mid:])
+	}
+}
+
+// parallelMerge把已排序的left和right稳定地归并到dst中，len(dst)必须等于len(left)+len(right)。
+// !!! 取较长一方的中间元素key，在另一方中二分查找key的位置，key在dst中的位置随之确定，
+// !!! key左边和右边的两部分互不相干，可以并行地归并。为了保证稳定，left中与key相等的元素排在key之前，
+// !!! right中与key相等的元素排在key之后。每次至少把较长的一方减半，递归深度是O(log n)。
+func (ps *parallelSorter[S, E]) parallelMerge(dst, left, right S) {
+	if len(dst) <= ps.grain {
+		mergeInto(dst, left, right, ps.cmp)
+		return
+	}
+	var i, j int // left[:i]和right[:j]归并到key的左边
+	if len(left) >= len(right) {
+		i = len(left) / 2
+		key := left[i]
+		j = sort.Search(len(right), func(k int) bool { return ps.cmp(right[k], key) >= 0 })
+		dst[i+j] = key
+		ps.fork2(
+			func() { ps.parallelMerge(dst[:i+j], left[:i], right[:j]) },
+			func() { ps.parallelMerge(dst[i+j+1:], left[i+1:], right[j:]) },
+		)
+	} else {
+		j = len(right) / 2
+		key := right[j]
+		i = sort.Search(len(left), func(k int) bool { return ps.cmp(left[k], key) > 0 })
+		dst[i+j] = key
+		ps.fork2(
+			func() { ps.parallelMerge(dst[:i+j], left[:i], right[:j]) },
+			func() { ps.parallelMerge(dst[i+j+1:], left[i:], right[j+1:]) },
+		)
+	}
+}
+
+// ParallelQuickSort是并行的快速排序，不需要辅助切片，但不是稳定的。
+// !!! 与IntroSort一样用九数取中选取基元并做三路划分，划分之后的两侧分别交给两个goroutine；
+// !!! 递归过深时改用堆排序，粒度以下的分区用IntroSort排序。
+// !!! 每一次划分都是顺序的，最顶层的划分要处理全部n个元素，因此加速比通常不如ParallelMergeSort。
+func ParallelQuickSort[S ~[]E, E cmp.Ordered](s S, grain, workers int) {
+	ParallelQuickSortFunc(s, grain, workers, cmp.Compare[E])
+}
+
+// ParallelQuickSortFunc与ParallelQuickSort相同，但使用cmp函数比较元素
+func ParallelQuickSortFunc[S ~[]E, E any](s S, grain, workers int, cmp func(a, b E) int) {
+	if len(s) < 2 {
+		return
+	}
+	ps := newParallelSorter[S](grain, workers, cmp)
+	ps.quickSort(s, 2*bits.Len(uint(len(s))))
+}
+
+func (ps *parallelSorter[S, E]) quickSort(s S, depthLimit int) {
+	if len(s) <= ps.grain {
+		introSort(s, depthLimit, ps.cmp)
+		return
+	}
+	if depthLimit == 0 {
+		HeapSortFunc(s, ps.cmp)
+		return
+	}
+	swap(s, 0, choosePivot(s, ps.cmp))
+	lt, gt := Partition3Func(s, ps.cmp)
+	ps.fork2(
+		func() { ps.quickSort(s[:lt], depthLimit-1) },
+		func() { ps.quickSort(s[gt:], depthLimit-1) },
+	)
+}
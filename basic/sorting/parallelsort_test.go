@@ -0,0 +1,80 @@
+package sorting
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// 很小的粒度使得testing/quick生成的短切片也会经过并行的划分和归并
+func TestParallelSortProperties(t *testing.T) {
+	checkProperty(t, "ParallelSort", sortedLikeStdlib(ParallelSort[[]int]))
+	checkProperty(t, "ParallelSortFunc", sortedLikeStdlibFunc(ParallelSortFunc[[]string]))
+	for _, grain := range []int{1, 2, 5} {
+		for _, workers := range []int{1, 4} {
+			name := fmt.Sprintf("(grain=%d, workers=%d)", grain, workers)
+			checkProperty(t, "ParallelMergeSort"+name, sortedLikeStdlib(func(s []int) {
+				ParallelMergeSort(s, grain, workers)
+			}))
+			checkProperty(t, "ParallelQuickSort"+name, sortedLikeStdlib(func(s []int8) {
+				ParallelQuickSort(s, grain, workers)
+			}))
+			checkProperty(t, "ParallelMergeSortFunc"+name, sortedLikeStdlibFunc(func(s []string, cmp func(a, b string) int) {
+				ParallelMergeSortFunc(s, grain, workers, cmp)
+			}))
+			checkProperty(t, "ParallelQuickSortFunc"+name, sortedLikeStdlibFunc(func(s []string, cmp func(a, b string) int) {
+				ParallelQuickSortFunc(s, grain, workers, cmp)
+			}))
+		}
+	}
+}
+
+func TestParallelMergeSortIsStable(t *testing.T) {
+	for input, gen := range stableInputs {
+		for _, grain := range []int{1, 16, 1000} {
+			keys := gen(100000)
+			records := make([]record, len(keys))
+			for i, k := range keys {
+				records[i] = record{key: k, tag: i}
+			}
+			want := slices.Clone(records)
+			slices.SortStableFunc(want, compareKeys)
+			ParallelMergeSortFunc(records, grain, 8, compareKeys)
+			if !slices.Equal(records, want) {
+				t.Errorf("ParallelMergeSort(%s, grain=%d)不是稳定的", input, grain)
+			}
+		}
+	}
+}
+
+func TestParallelSortLarge(t *testing.T) {
+	sorts := map[string]func([]int){
+		"ParallelSort":      ParallelSort[[]int],
+		"ParallelMergeSort": func(s []int) { ParallelMergeSort(s, 1000, 8) },
+		"ParallelQuickSort": func(s []int) { ParallelQuickSort(s, 1000, 8) },
+	}
+	inputs := map[string]func(n int) []int{
+		"random": func(n int) []int {
+			s := make([]int, n)
+			for i := range s {
+				s[i] = rand.Int()
+			}
+			return s
+		},
+	}
+	for name, gen := range adversarialInputs {
+		inputs[name] = gen
+	}
+	for name, sort := range sorts {
+		for input, gen := range inputs {
+			s := gen(200000)
+			want := slices.Clone(s)
+			slices.Sort(want)
+			sort(s)
+			if !slices.Equal(s, want) {
+				t.Errorf("%s(%s)的结果不正确", name, input)
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package sorting
+
+import "cmp"
+
+// QuickSort（快速排序）是一种就地排序算法。其主要思想是找到任何一个元素作为基元，把所有比该元素小的元素都放在
+// 其左侧，把所有比该元素大的元素都放在其右侧，这样在对左右两侧的子数组进行同样的操作，如此
+// 反复递归下去，就会完成排序。
+// !!! 在下面的算法中，为了便于操作，选取了最后一个元素作为基元。
+func QuickSort[S ~[]E, E cmp.Ordered](s S) {
+	QuickSortFunc(s, cmp.Compare[E])
+}
+
+// QuickSortFunc与QuickSort相同，但使用cmp函数比较元素
+func QuickSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	//!!! 下面的两种情况达到了不停与基元进行比较，形成左（小于）右（大于）两个子组数组的递归操作的“触底条件”
+	l := len(s)
+	if l == 1 || l == 0 {
+		return
+	}
+	if l == 2 {
+		if cmp(s[0], s[1]) > 0 {
+			swap(s, 0, 1)
+		}
+		return
+	}
+	//!!! 选取最后一个元素作为基元，那么如果最后一个元素比其前一个元素小，那么,
+	//!!! 二者就交换位置（这样就保证）,如果前一个元素小于或等于该元素，那么，
+	//!!! 就把这个证明比之小的元素与数组中第一个未与之相比的元素交换（使之变为基元元素的前一个元素），
+	//!!! 再重复上述操作，直至基元元素的序号与第一个未与之相比较的元素位置相同（相遇）就完成了左右
+	//!!! 两侧数组的整理。
+
+	baseIndex := l - 1                             //!!! 选取数组的最后一个元素基元
+	firstUnComparedElementIndex := 0               //!!! 数组中第一个未与基元比较的元素位置,从0开始。
+	for baseIndex != firstUnComparedElementIndex { //二者相遇
+		if cmp(s[baseIndex-1], s[baseIndex]) > 0 { //!!!如果基元比前一个元素小，二者就交换位置
+			swap(s, baseIndex-1, baseIndex)
+			baseIndex = baseIndex - 1
+		} else { //!!!否则，就将这个小于基元的前一个元素交换与前面第一个未与基元比较的元素进行交换,一方面比较出来小于基元的元素放在基元的左侧，另一方面以便下一次循环让基元与前一个未比较过的元素比较
+			swap(s, baseIndex-1, firstUnComparedElementIndex)
+			firstUnComparedElementIndex += 1
+		}
+	}
+	//对分治后的子数组继续进行同样的分治
+	QuickSortFunc(s[:baseIndex], cmp)
+	QuickSortFunc(s[baseIndex:], cmp)
+}
@@ -0,0 +1,165 @@
+package sorting
+
+import "slices"
+
+// RadixSort（基数排序）是一种不基于比较的线性排序方法，这里实现的是LSD（Least Significant Digit）基数排序：
+// !!! 把整数看作以256为基数（每个“数字”占8位）的数，从最低位的数字开始，每一趟都按照当前位的数字对所有元素
+// !!! 做一次稳定的计数排序（参见CountingSortFunc），处理完最高位的数字之后，所有元素就排好序了。
+// !!! 由于每一趟都是稳定的，低位数字已经排好的顺序在高位数字相同时得以保持。
+// !!! 对于有符号整数，补码表示中负数的最高位（符号位）是1，直接按无符号数排序会把负数排在正数之后，
+// !!! 因此把符号位取反后再作为排序的键，这样负数的键就小于正数的键，而同号整数之间的顺序不变。
+// !!! 对于w位的整数，需要w/8趟，每一趟是O(n)，总的复杂度是O(w/8 * n)，需要一个与s等长的辅助切片；
+// !!! 如果某一趟所有元素的数字都相同（例如较小的正整数的高位都是0），这一趟就被跳过。
+func RadixSort[S ~[]E, E Integer](s S) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+	var zero E
+	width := 0 //!!! 整数类型的位数
+	for x := ^zero; x != 0; x <<= 1 {
+		width++
+	}
+	mask := ^uint64(0) >> (64 - width) //!!! 有符号的负数转换为uint64时会做符号扩展，需要截取低width位
+	var signBit uint64
+	if ^zero < zero { //!!! 有符号整数的^0是-1
+		signBit = 1 << (width - 1)
+	}
+	key := func(e E) uint64 {
+		return uint64(e)&mask ^ signBit
+	}
+
+	buf := make(S, n)
+	src, dst := s, buf
+	for shift := 0; shift < width; shift += 8 {
+		var counts [256]int
+		for _, e := range src {
+			counts[key(e)>>shift&0xff]++
+		}
+		if counts[key(src[0])>>shift&0xff] == n { //!!! 所有元素在这一位上的数字都相同，跳过这一趟
+			continue
+		}
+		//!!! 把计数转换为每个数字在结果中的起始位置
+		start := 0
+		for d, c := range counts {
+			counts[d] = start
+			start += c
+		}
+		for _, e := range src {
+			d := key(e) >> shift & 0xff
+			dst[counts[d]] = e
+			counts[d]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] { //!!! 经过奇数趟之后，排序的结果在辅助切片中
+		copy(s, src)
+	}
+}
+
+// msdInsertionCutoff是MSD基数排序中改用插入排序的桶的规模
+const msdInsertionCutoff = 32
+
+// MSDRadixSort是对字符串或字节切片进行的MSD（Most Significant Digit）基数排序，结果与slices.Sort的顺序（字典序）相同。
+// !!! 把每个元素看作一个比特串，每次取radixBits位（8、11或16，必须在1到16之间）作为一个“数字”，
+// !!! 从最高位的数字（即元素的开头）开始，按照当前的数字把元素分到2^radixBits个桶中，再对每个桶递归地处理下一个数字。
+// !!! 与LSD不同，MSD只需处理区分各个元素所必需的前缀，并且可以处理长度不同的元素：
+// !!! 在当前位置已经结束的元素放在桶的最前面，它们只可能在末尾的零字节个数上有所不同（例如"a"与"a\x00"），按长度排序即可。
+// !!! 元素个数不超过msdInsertionCutoff的桶改用插入排序，以免为大量的小桶付出分配计数数组的代价。
+// !!! radixBits越大，递归的层数越少，但每一层的计数数组越大（16位时有65536个桶），适合规模更大的输入。
+func MSDRadixSort[S ~[]E, E ~string | ~[]byte](s S, radixBits int) {
+	if radixBits < 1 || radixBits > 16 {
+		panic("基数的位数必须在1到16之间")
+	}
+	if len(s) < 2 {
+		return
+	}
+	m := &msdSorter[S, E]{bits: radixBits, buf: make(S, len(s))}
+	m.sort(s, 0, 0)
+}
+
+// msdSorter保存MSD基数排序所用的辅助切片和每一层递归的计数数组
+type msdSorter[S ~[]E, E ~string | ~[]byte] struct {
+	bits   int
+	buf    S       // 与被排序的切片等长的辅助切片
+	counts [][]int // counts[depth]是第depth层递归所用的计数数组，同一层的递归调用依次复用它
+}
+
+// digit返回元素e从第off位开始的bits位数字，超出元素末尾的位按0处理。
+func (m *msdSorter[S, E]) digit(e E, off int) int {
+	i := off / 8
+	var w uint32 //!!! 从第off/8个字节开始读取3个字节（24位），足以容纳任意位置开始的16位数字
+	for k := 0; k < 3; k++ {
+		w <<= 8
+		if i+k < len(e) {
+			w |= uint32(e[i+k])
+		}
+	}
+	return int(w>>(24-off%8-m.bits)) & (1<<m.bits - 1)
+}
+
+// sort对s中的元素按照从第off位开始的数字排序，s中的元素在第off位之前的部分都是相同的。
+func (m *msdSorter[S, E]) sort(s S, off, depth int) {
+	if len(s) <= msdInsertionCutoff {
+		InsertionSortFunc(s, compareBytes[E])
+		return
+	}
+	//!!! 把在第off位已经结束的元素移到最前面，并按长度排序
+	ended := 0
+	for i, e := range s {
+		if len(e)*8 <= off {
+			s[i], s[ended] = s[ended], s[i]
+			ended++
+		}
+	}
+	if ended > 0 {
+		slices.SortFunc(s[:ended], func(a, b E) int { return len(a) - len(b) })
+		s = s[ended:]
+		if len(s) < 2 {
+			return
+		}
+	}
+
+	if depth == len(m.counts) {
+		m.counts = append(m.counts, make([]int, 1<<m.bits))
+	}
+	counts := m.counts[depth]
+	clear(counts)
+	for _, e := range s {
+		counts[m.digit(e, off)]++
+	}
+	//!!! 把计数转换为每个桶在结果中的起始位置
+	start := 0
+	for d, c := range counts {
+		counts[d] = start
+		start += c
+	}
+	buf := m.buf[:len(s)]
+	for _, e := range s {
+		d := m.digit(e, off)
+		buf[counts[d]] = e
+		counts[d]++
+	}
+	copy(s, buf)
+	//!!! 放置元素之后，counts[d]成为数字为d的桶的结束位置，也就是下一个桶的起始位置
+	lo := 0
+	for _, hi := range counts {
+		if hi-lo > 1 {
+			m.sort(s[lo:hi], off+m.bits, depth+1)
+		}
+		lo = hi
+	}
+}
+
+// compareBytes按字典序比较两个字符串或字节切片
+func compareBytes[E ~string | ~[]byte](a, b E) int {
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
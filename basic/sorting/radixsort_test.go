@@ -0,0 +1,78 @@
+package sorting
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestRadixSortProperties(t *testing.T) {
+	checkProperty(t, "RadixSort(int)", sortedLikeStdlib(RadixSort[[]int]))
+	checkProperty(t, "RadixSort(int8)", sortedLikeStdlib(RadixSort[[]int8]))
+	checkProperty(t, "RadixSort(int16)", sortedLikeStdlib(RadixSort[[]int16]))
+	checkProperty(t, "RadixSort(int32)", sortedLikeStdlib(RadixSort[[]int32]))
+	checkProperty(t, "RadixSort(int64)", sortedLikeStdlib(RadixSort[[]int64]))
+	checkProperty(t, "RadixSort(uint)", sortedLikeStdlib(RadixSort[[]uint]))
+	checkProperty(t, "RadixSort(uint8)", sortedLikeStdlib(RadixSort[[]uint8]))
+	checkProperty(t, "RadixSort(uint16)", sortedLikeStdlib(RadixSort[[]uint16]))
+	checkProperty(t, "RadixSort(uint32)", sortedLikeStdlib(RadixSort[[]uint32]))
+	checkProperty(t, "RadixSort(uint64)", sortedLikeStdlib(RadixSort[[]uint64]))
+	//!!! 只有少数几个不同值（会跳过若干趟）以及边界值
+	s := []int64{math.MinInt64, math.MaxInt64, -1, 0, 1, math.MinInt64 + 1, 3, -3}
+	want := slices.Sorted(slices.Values(s))
+	RadixSort(s)
+	if !slices.Equal(s, want) {
+		t.Errorf("RadixSort的结果应为%v，实际为%v", want, s)
+	}
+}
+
+// randomStrings生成n个由少数几个字符组成的随机字符串，以产生大量的公共前缀、重复元素和互为前缀的元素
+func randomStrings(n int) []string {
+	alphabet := []byte{0, 1, 'a', 'b', 0xff}
+	s := make([]string, n)
+	for i := range s {
+		b := make([]byte, rand.IntN(8))
+		for j := range b {
+			b[j] = alphabet[rand.IntN(len(alphabet))]
+		}
+		s[i] = string(b)
+	}
+	return s
+}
+
+func TestMSDRadixSort(t *testing.T) {
+	for _, bits := range []int{1, 3, 8, 11, 16} {
+		t.Run(fmt.Sprintf("bits=%d", bits), func(t *testing.T) {
+			checkProperty(t, "strings", sortedLikeStdlib(func(s []string) { MSDRadixSort(s, bits) }))
+			for _, n := range []int{0, 1, 2, 31, 33, 1000, 20000} {
+				s := randomStrings(n)
+				want := slices.Sorted(slices.Values(s))
+				MSDRadixSort(s, bits)
+				if !slices.Equal(s, want) {
+					t.Fatalf("n=%d: MSDRadixSort的结果与slices.Sort不同", n)
+				}
+				//!!! [][]byte的结果应与bytes.Compare的顺序相同
+				b := make([][]byte, n)
+				for i := range s {
+					b[i] = []byte(want[(i*7)%max(n, 1)])
+				}
+				MSDRadixSort(b, bits)
+				if !slices.IsSortedFunc(b, bytes.Compare) {
+					t.Fatalf("n=%d: [][]byte的MSDRadixSort结果不是有序的", n)
+				}
+			}
+		})
+	}
+}
+
+func TestMSDRadixSortInvalidBits(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("radixBits超出[1,16]时应抛出panic")
+		}
+	}()
+	MSDRadixSort([]string{"b", "a"}, 17)
+}
@@ -0,0 +1,22 @@
+package sorting
+
+import "cmp"
+
+// SelectionSort对给定的切片进行选择排序
+// !!! 选择排序的思路遍历切片全部数据，找到最小值元素作为第一个元素(与第一个元素交换)，
+// !!! 然后再找次最小值作为第二个元素， 依次类推，完成排序
+func SelectionSort[S ~[]E, E cmp.Ordered](s S) {
+	SelectionSortFunc(s, cmp.Compare[E])
+}
+
+// SelectionSortFunc与SelectionSort相同，但使用cmp函数比较元素
+func SelectionSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	for i := 0; i < len(s); i++ {
+		for j := i + 1; j < len(s); j++ {
+			if cmp(s[j], s[i]) <= 0 {
+				//!!!将i处元素j处元素交换,使得i处元素仍旧当前最小
+				swap(s, i, j)
+			}
+		}
+	}
+}
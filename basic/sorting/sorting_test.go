@@ -0,0 +1,127 @@
+package sorting
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// 以下是基于性质（property-based）的测试：testing/quick随机生成大量的输入切片，
+// 每个排序算法的结果都必须与slices.Sort（或slices.SortFunc）的结果相同。
+
+// sortedLikeStdlib检查sort对s排序的结果是否与slices.Sort的结果相同
+func sortedLikeStdlib[E cmp.Ordered](sort func([]E)) func([]E) bool {
+	return func(s []E) bool {
+		got, want := slices.Clone(s), slices.Clone(s)
+		sort(got)
+		slices.Sort(want)
+		return slices.Equal(got, want)
+	}
+}
+
+// descending是按照从大到小的顺序比较字符串的比较函数，用于检验各个Func变体
+func descending(a, b string) int {
+	return strings.Compare(b, a)
+}
+
+// sortedLikeStdlibFunc检查sort按照descending对s排序的结果是否与slices.SortFunc的结果相同
+func sortedLikeStdlibFunc(sort func([]string, func(a, b string) int)) func([]string) bool {
+	return func(s []string) bool {
+		got, want := slices.Clone(s), slices.Clone(s)
+		sort(got, descending)
+		slices.SortFunc(want, descending)
+		return slices.Equal(got, want)
+	}
+}
+
+var quickConfig = &quick.Config{MaxCount: 500}
+
+func checkProperty(t *testing.T, name string, f any) {
+	t.Helper()
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Errorf("%s: %v", name, err)
+	}
+}
+
+func TestSortProperties(t *testing.T) {
+	checkProperty(t, "SelectionSort", sortedLikeStdlib(SelectionSort[[]int]))
+	checkProperty(t, "QuickSort", sortedLikeStdlib(QuickSort[[]int]))
+	checkProperty(t, "HeapSort", sortedLikeStdlib(HeapSort[[]int]))
+	checkProperty(t, "InsertionSort", sortedLikeStdlib(InsertionSort[[]int]))
+	checkProperty(t, "MergeSort", sortedLikeStdlib(MergeSort[[]int]))
+	for _, granularity := range []int{0, 1, 3, 4, 5} {
+		checkProperty(t, fmt.Sprintf("MergeSort2(%d)", granularity), sortedLikeStdlib(func(s []int) {
+			MergeSort2(s, granularity, SelectionSort[[]int])
+		}))
+	}
+	checkProperty(t, "insertionSort2", sortedLikeStdlib(func(s []int) { insertionSort2(s, cmp.Compare[int]) }))
+	checkProperty(t, "CountingSort(uint8)", sortedLikeStdlib(func(s []uint8) { CountingSort(s, 0, 255) }))
+	checkProperty(t, "CountingSort(int8)", sortedLikeStdlib(func(s []int8) { CountingSort(s, -128, 127) }))
+	checkProperty(t, "MergeSortedLists", func(left, right []int) bool {
+		slices.Sort(left)
+		slices.Sort(right)
+		want := slices.Concat(left, right)
+		slices.Sort(want)
+		return slices.Equal(MergeSortedLists(left, right), want)
+	})
+	//!!! 浮点数与字符串
+	checkProperty(t, "HeapSort(float64)", sortedLikeStdlib(HeapSort[[]float64]))
+	checkProperty(t, "QuickSort(string)", sortedLikeStdlib(QuickSort[[]string]))
+}
+
+func TestSortFuncProperties(t *testing.T) {
+	checkProperty(t, "SelectionSortFunc", sortedLikeStdlibFunc(SelectionSortFunc[[]string]))
+	checkProperty(t, "QuickSortFunc", sortedLikeStdlibFunc(QuickSortFunc[[]string]))
+	checkProperty(t, "HeapSortFunc", sortedLikeStdlibFunc(HeapSortFunc[[]string]))
+	checkProperty(t, "InsertionSortFunc", sortedLikeStdlibFunc(InsertionSortFunc[[]string]))
+	checkProperty(t, "MergeSortFunc", sortedLikeStdlibFunc(MergeSortFunc[[]string]))
+	checkProperty(t, "MergeSort2Func", sortedLikeStdlibFunc(func(s []string, cmp func(a, b string) int) {
+		MergeSort2Func(s, 4, func(sub []string) { InsertionSortFunc(sub, cmp) }, cmp)
+	}))
+	checkProperty(t, "MergeSortedListsFunc", func(left, right []string) bool {
+		slices.SortFunc(left, descending)
+		slices.SortFunc(right, descending)
+		want := slices.Concat(left, right)
+		slices.SortFunc(want, descending)
+		return slices.Equal(MergeSortedListsFunc(left, right, descending), want)
+	})
+	//!!! CountingSortFunc按照key排序，并且是稳定的，因此结果应与slices.SortStableFunc相同
+	checkProperty(t, "CountingSortFunc", func(s []string) bool {
+		key := func(e string) int { return len(e) % 10 }
+		got, want := slices.Clone(s), slices.Clone(s)
+		CountingSortFunc(got, key, 0, 9)
+		slices.SortStableFunc(want, func(a, b string) int { return cmp.Compare(key(a), key(b)) })
+		return slices.Equal(got, want)
+	})
+}
+
+func TestMoveBack(t *testing.T) {
+	s := []int{0, 2, 4, 6, 8}
+	moveBackOneElmentThenInsert(s, 3, 3)
+	if want := []int{0, 2, 4, 3, 6}; !slices.Equal(s, want) {
+		t.Errorf("应为%v，实际为%v", want, s)
+	}
+}
+
+// 对于已排好序的大规模输入，最初的heapSort需要O(n²)次比较，而HeapSort只需要O(n log n)次比较
+func TestHeapSortIsLinearithmic(t *testing.T) {
+	n := 1 << 16
+	s := make([]int, n)
+	for i := range s {
+		s[i] = n - i
+	}
+	comparisons := 0
+	HeapSortFunc(s, func(a, b int) int {
+		comparisons++
+		return cmp.Compare(a, b)
+	})
+	if !slices.IsSorted(s) {
+		t.Fatalf("HeapSort的结果不是有序的")
+	}
+	if limit := 3 * n * 16; comparisons > limit {
+		t.Errorf("比较次数%d超过了3*n*log(n)的上限%d", comparisons, limit)
+	}
+}
@@ -0,0 +1,412 @@
+package sorting
+
+import (
+	"cmp"
+	"slices"
+)
+
+// !!! 如果排序后相等的元素保持原来的相对顺序，那么这个排序算法就是“稳定（stable）”的，
+// !!! 例如先按姓名、再按年龄对记录排序时，年龄相同的记录仍然按姓名排列。
+// !!! MergeSort、MergeSort2通过sortOrderedListsByInsertion就地归并，最坏情况下是O(n²)的，而且并不稳定；
+// !!! MergeSortedLists每次调用都要分配新的切片。本文件中的两种稳定排序都只使用一个可以复用的辅助切片：
+// !!! BottomUpMergeSort是自底向上的归并排序，TimSort则利用输入中已有的有序片段（run），并在归并时使用“飞奔（galloping）”模式。
+
+// SortStable对s进行稳定排序，等价于TimSort
+func SortStable[S ~[]E, E cmp.Ordered](s S) {
+	TimSortFunc(s, cmp.Compare[E])
+}
+
+// SortStableFunc是稳定排序的通用入口，使用cmp函数比较元素，相等的元素保持原来的相对顺序，等价于TimSortFunc。
+func SortStableFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	TimSortFunc(s, cmp)
+}
+
+// bottomUpBlockSize是自底向上归并排序中先用插入排序排好的小块的大小
+const bottomUpBlockSize = 16
+
+// BottomUpMergeSort是自底向上（bottom-up）的稳定归并排序。
+// !!! 与递归地二分数组不同，自底向上的归并排序先用插入排序把每bottomUpBlockSize个相邻的元素排好，
+// !!! 然后把相邻的两个已排序的块归并为一个更大的块，块的大小每一趟翻倍，直到整个数组成为一个块。
+// !!! 每一趟都把结果从源切片归并到目标切片，下一趟再交换二者的角色，因此整个排序只需要一个与s等长的辅助切片。
+func BottomUpMergeSort[S ~[]E, E cmp.Ordered](s S) {
+	BottomUpMergeSortFunc(s, cmp.Compare[E])
+}
+
+// BottomUpMergeSortFunc与BottomUpMergeSort相同，但使用cmp函数比较元素
+func BottomUpMergeSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	n := len(s)
+	for lo := 0; lo < n; lo += bottomUpBlockSize {
+		InsertionSortFunc(s[lo:min(lo+bottomUpBlockSize, n)], cmp) //!!! 插入排序是稳定的
+	}
+	if n <= bottomUpBlockSize {
+		return
+	}
+	buf := make(S, n)
+	src, dst := s, buf
+	for width := bottomUpBlockSize; width < n; width *= 2 {
+		for lo := 0; lo < n; lo += 2 * width {
+			mid, hi := min(lo+width, n), min(lo+2*width, n)
+			mergeInto(dst[lo:hi], src[lo:mid], src[mid:hi], cmp)
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] { //!!! 经过奇数趟之后，排序的结果在辅助切片中
+		copy(s, src)
+	}
+}
+
+// mergeInto把已排序的left和right稳定地归并到dst中，len(dst)必须等于len(left)+len(right)。
+// !!! 只有当right的元素严格小于left的元素时才先取right的元素，因此相等的元素中left的排在前面。
+func mergeInto[S ~[]E, E any](dst, left, right S, cmp func(a, b E) int) {
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if cmp(right[j], left[i]) < 0 {
+			dst[k] = right[j]
+			j++
+		} else {
+			dst[k] = left[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], left[i:])
+	copy(dst[k:], right[j:])
+}
+
+const (
+	timMinMerge  = 32 // 元素个数少于它的数组直接用二分插入排序
+	timMinGallop = 7  // 进入飞奔模式的初始阈值
+)
+
+// TimSort是Tim Peters为Python设计的稳定排序算法，也是Java对象数组的默认排序算法。
+// !!! 1. 从左向右扫描数组，找出已有的有序片段（run）：非递减的片段保持不变，严格递减的片段就地反转（严格递减才能保证稳定）；
+// !!!    太短的片段用二分插入排序扩展到minRun个元素（minRun在16到32之间，使片段的个数接近2的整数次幂）。
+// !!! 2. 把片段压入栈中，并维持栈中相邻片段长度的不变式（类似斐波那契数列），不满足时就归并相邻的片段，
+// !!!    这保证了归并是“平衡”的，栈的深度是O(log n)。
+// !!! 3. 归并两个片段时，如果发现一个片段连续多次“胜出”，就进入飞奔（galloping）模式：
+// !!!    用指数搜索加二分搜索一次找出可以整块移动的元素，这使得对部分有序的数据的归并只需O(log n)次比较。
+// !!! 对于已排序或逆序的输入，TimSort只需O(n)次比较，最坏情况是O(n log n)。
+func TimSort[S ~[]E, E cmp.Ordered](s S) {
+	TimSortFunc(s, cmp.Compare[E])
+}
+
+// TimSortFunc与TimSort相同，但使用cmp函数比较元素
+func TimSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+	if n < timMinMerge {
+		binaryInsertionSort(s, countRunAndMakeAscending(s, cmp), cmp)
+		return
+	}
+	ts := &timSorter[S, E]{s: s, cmp: cmp, minGallop: timMinGallop}
+	minRun := minRunLength(n)
+	for lo := 0; lo < n; {
+		runLen := countRunAndMakeAscending(s[lo:], cmp)
+		if runLen < minRun { //!!! 片段太短，用二分插入排序扩展到minRun个元素
+			force := min(minRun, n-lo)
+			binaryInsertionSort(s[lo:lo+force], runLen, cmp)
+			runLen = force
+		}
+		ts.runs = append(ts.runs, timRun{lo, runLen})
+		ts.mergeCollapse()
+		lo += runLen
+	}
+	ts.mergeForceCollapse()
+}
+
+// timRun是s[base:base+len]的有序片段
+type timRun struct {
+	base, len int
+}
+
+// timSorter保存TimSort的状态：片段栈、辅助切片和当前的飞奔阈值
+type timSorter[S ~[]E, E any] struct {
+	s         S
+	cmp       func(a, b E) int
+	runs      []timRun
+	tmp       S   // 归并所用的辅助切片，按需增长，在多次归并之间复用
+	minGallop int // 进入飞奔模式的阈值，飞奔有效时降低，无效时升高
+}
+
+// minRunLength返回n个元素的数组所用的最小片段长度
+func minRunLength(n int) int {
+	r := 0 //!!! 只要移出的低位中有1，r就为1
+	for n >= timMinMerge {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+// countRunAndMakeAscending返回从s[0]开始的有序片段的长度，如果片段是严格递减的，就把它反转为递增的
+func countRunAndMakeAscending[S ~[]E, E any](s S, cmp func(a, b E) int) int {
+	n := len(s)
+	if n < 2 {
+		return n
+	}
+	i := 2
+	if cmp(s[1], s[0]) < 0 {
+		for i < n && cmp(s[i], s[i-1]) < 0 {
+			i++
+		}
+		slices.Reverse(s[:i])
+	} else {
+		for i < n && cmp(s[i], s[i-1]) >= 0 {
+			i++
+		}
+	}
+	return i
+}
+
+// binaryInsertionSort对s进行二分插入排序，s[:start]已经是有序的。
+// !!! 插入位置是最后一个不大于待插入元素的元素之后，因此是稳定的。
+func binaryInsertionSort[S ~[]E, E any](s S, start int, cmp func(a, b E) int) {
+	for i := max(start, 1); i < len(s); i++ {
+		pivot := s[i]
+		lo, hi := 0, i
+		for lo < hi {
+			mid := int(uint(lo+hi) >> 1)
+			if cmp(pivot, s[mid]) < 0 {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		copy(s[lo+1:i+1], s[lo:i])
+		s[lo] = pivot
+	}
+}
+
+// mergeCollapse检查栈顶的片段，在不满足以下不变式时归并相邻的片段（X、Y、Z是栈顶的三个片段，Z在栈顶）：
+// !!! len(X) > len(Y) + len(Z) 且 len(Y) > len(Z)。
+// !!! 这里采用了de Gouw等人在2015年修正后的版本，同时检查了栈顶下方的第四个片段。
+func (ts *timSorter[S, E]) mergeCollapse() {
+	for len(ts.runs) > 1 {
+		n := len(ts.runs) - 2
+		r := ts.runs
+		if n > 0 && r[n-1].len <= r[n].len+r[n+1].len || n > 1 && r[n-2].len <= r[n-1].len+r[n].len {
+			if r[n-1].len < r[n+1].len {
+				n--
+			}
+		} else if r[n].len > r[n+1].len {
+			break
+		}
+		ts.mergeAt(n)
+	}
+}
+
+// mergeForceCollapse归并栈中所有的片段，排序完成
+func (ts *timSorter[S, E]) mergeForceCollapse() {
+	for len(ts.runs) > 1 {
+		n := len(ts.runs) - 2
+		if n > 0 && ts.runs[n-1].len < ts.runs[n+1].len {
+			n--
+		}
+		ts.mergeAt(n)
+	}
+}
+
+// mergeAt归并栈中第i和第i+1个片段
+func (ts *timSorter[S, E]) mergeAt(i int) {
+	base1, len1 := ts.runs[i].base, ts.runs[i].len
+	base2, len2 := ts.runs[i+1].base, ts.runs[i+1].len
+	ts.runs[i].len = len1 + len2
+	ts.runs = append(ts.runs[:i+1], ts.runs[i+2:]...)
+
+	s := ts.s
+	//!!! 第一个片段中不大于s[base2]的前缀已经在正确的位置上，无需参与归并
+	k := gallopRight(s[base2], s[base1:base1+len1], 0, ts.cmp)
+	base1 += k
+	len1 -= k
+	if len1 == 0 {
+		return
+	}
+	//!!! 第二个片段中不小于第一个片段最后一个元素的后缀也已经在正确的位置上
+	len2 = gallopLeft(s[base1+len1-1], s[base2:base2+len2], len2-1, ts.cmp)
+	if len2 == 0 {
+		return
+	}
+	//!!! 把较短的片段复制到辅助切片中，从而只需要min(len1, len2)的辅助空间
+	if len1 <= len2 {
+		ts.mergeLo(base1, len1, base2, len2)
+	} else {
+		ts.mergeHi(base1, len1, base2, len2)
+	}
+}
+
+// ensureTmp返回至少能容纳n个元素的辅助切片
+func (ts *timSorter[S, E]) ensureTmp(n int) S {
+	if len(ts.tmp) < n {
+		ts.tmp = make(S, max(n, min(2*len(ts.tmp), len(ts.s)/2)))
+	}
+	return ts.tmp[:n]
+}
+
+// mergeLo从左向右归并相邻的两个片段，要求len1 <= len2，第一个片段被复制到辅助切片中。
+func (ts *timSorter[S, E]) mergeLo(base1, len1, base2, len2 int) {
+	s, cmp := ts.s, ts.cmp
+	tmp := ts.ensureTmp(len1)
+	copy(tmp, s[base1:base1+len1])
+	c1, c2, dest, end2 := 0, base2, base1, base2+len2 //!!! dest总是不超过c2，因此不会覆盖尚未归并的元素
+	minGallop := ts.minGallop
+outer:
+	for {
+		//!!! 逐个比较，直到某个片段连续胜出minGallop次
+		count1, count2 := 0, 0
+		for count1 < minGallop && count2 < minGallop {
+			if cmp(s[c2], tmp[c1]) < 0 {
+				s[dest] = s[c2]
+				c2++
+				count1, count2 = 0, count2+1
+				if dest++; c2 == end2 {
+					break outer
+				}
+			} else {
+				s[dest] = tmp[c1]
+				c1++
+				count1, count2 = count1+1, 0
+				if dest++; c1 == len1 {
+					break outer
+				}
+			}
+		}
+		//!!! 飞奔模式：一次找出可以整块移动的元素，直到整块移动的元素都少于timMinGallop个
+		for {
+			k1 := gallopRight(s[c2], tmp[c1:len1], 0, cmp)
+			dest += copy(s[dest:], tmp[c1:c1+k1])
+			if c1 += k1; c1 == len1 {
+				break outer
+			}
+			k2 := gallopLeft(tmp[c1], s[c2:end2], 0, cmp)
+			dest += copy(s[dest:], s[c2:c2+k2])
+			if c2 += k2; c2 == end2 {
+				break outer
+			}
+			minGallop = max(minGallop-1, 1) //!!! 飞奔有效，降低阈值
+			if k1 < timMinGallop && k2 < timMinGallop {
+				break
+			}
+		}
+		minGallop += 2 //!!! 飞奔无效，提高阈值
+	}
+	ts.minGallop = max(minGallop, 1)
+	copy(s[dest:], tmp[c1:len1]) //!!! 第二个片段剩余的元素已经在正确的位置上
+}
+
+// mergeHi从右向左归并相邻的两个片段，要求len1 > len2，第二个片段被复制到辅助切片中。
+func (ts *timSorter[S, E]) mergeHi(base1, len1, base2, len2 int) {
+	s, cmp := ts.s, ts.cmp
+	tmp := ts.ensureTmp(len2)
+	copy(tmp, s[base2:base2+len2])
+	//!!! c1、c2、dest都是“下一个位置之后”的位置，dest总是不小于c1
+	c1, c2, dest := base1+len1, len2, base2+len2
+	minGallop := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0
+		for count1 < minGallop && count2 < minGallop {
+			dest--
+			if cmp(tmp[c2-1], s[c1-1]) < 0 { //!!! 相等时先取第二个片段的元素放在右边，保证稳定
+				s[dest] = s[c1-1]
+				c1--
+				count1, count2 = count1+1, 0
+				if c1 == base1 {
+					break outer
+				}
+			} else {
+				s[dest] = tmp[c2-1]
+				c2--
+				count1, count2 = 0, count2+1
+				if c2 == 0 {
+					break outer
+				}
+			}
+		}
+		for {
+			//!!! 第一个片段中严格大于tmp[c2-1]的后缀可以整块右移
+			k1 := c1 - base1 - gallopRight(tmp[c2-1], s[base1:c1], c1-base1-1, cmp)
+			copy(s[dest-k1:dest], s[c1-k1:c1])
+			dest -= k1
+			if c1 -= k1; c1 == base1 {
+				break outer
+			}
+			//!!! 辅助切片中不小于s[c1-1]的后缀可以整块移动
+			k2 := c2 - gallopLeft(s[c1-1], tmp[:c2], c2-1, cmp)
+			copy(s[dest-k2:dest], tmp[c2-k2:c2])
+			dest -= k2
+			if c2 -= k2; c2 == 0 {
+				break outer
+			}
+			minGallop = max(minGallop-1, 1)
+			if k1 < timMinGallop && k2 < timMinGallop {
+				break
+			}
+		}
+		minGallop += 2
+	}
+	ts.minGallop = max(minGallop, 1)
+	copy(s[dest-c2:dest], tmp[:c2]) //!!! 第一个片段剩余的元素已经在正确的位置上
+}
+
+// gallopLeft返回key在有序切片s中的插入位置，相等的元素排在key之后，即s中小于key的元素个数。
+// !!! 从hint位置开始以1、3、7、15……的步长“飞奔”，找到包含插入位置的区间后再做二分搜索，
+// !!! 当插入位置离hint很近时只需要很少的比较。
+func gallopLeft[S ~[]E, E any](key E, s S, hint int, cmp func(a, b E) int) int {
+	lastOfs, ofs := 0, 1
+	if cmp(key, s[hint]) > 0 { //!!! 向右飞奔，直到s[hint+lastOfs] < key <= s[hint+ofs]
+		maxOfs := len(s) - hint
+		for ofs < maxOfs && cmp(key, s[hint+ofs]) > 0 {
+			lastOfs, ofs = ofs, ofs<<1+1
+		}
+		ofs = min(ofs, maxOfs)
+		lastOfs, ofs = lastOfs+hint, ofs+hint
+	} else { //!!! 向左飞奔，直到s[hint-ofs] < key <= s[hint-lastOfs]
+		maxOfs := hint + 1
+		for ofs < maxOfs && cmp(key, s[hint-ofs]) <= 0 {
+			lastOfs, ofs = ofs, ofs<<1+1
+		}
+		ofs = min(ofs, maxOfs)
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	}
+	//!!! 此时s[lastOfs] < key <= s[ofs]，在(lastOfs, ofs]中二分搜索
+	for lastOfs++; lastOfs < ofs; {
+		m := lastOfs + (ofs-lastOfs)/2
+		if cmp(key, s[m]) > 0 {
+			lastOfs = m + 1
+		} else {
+			ofs = m
+		}
+	}
+	return ofs
+}
+
+// gallopRight与gallopLeft相同，但相等的元素排在key之前，即返回s中不大于key的元素个数。
+func gallopRight[S ~[]E, E any](key E, s S, hint int, cmp func(a, b E) int) int {
+	lastOfs, ofs := 0, 1
+	if cmp(key, s[hint]) < 0 { //!!! 向左飞奔，直到s[hint-ofs] <= key < s[hint-lastOfs]
+		maxOfs := hint + 1
+		for ofs < maxOfs && cmp(key, s[hint-ofs]) < 0 {
+			lastOfs, ofs = ofs, ofs<<1+1
+		}
+		ofs = min(ofs, maxOfs)
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	} else { //!!! 向右飞奔，直到s[hint+lastOfs] <= key < s[hint+ofs]
+		maxOfs := len(s) - hint
+		for ofs < maxOfs && cmp(key, s[hint+ofs]) >= 0 {
+			lastOfs, ofs = ofs, ofs<<1+1
+		}
+		ofs = min(ofs, maxOfs)
+		lastOfs, ofs = lastOfs+hint, ofs+hint
+	}
+	for lastOfs++; lastOfs < ofs; {
+		m := lastOfs + (ofs-lastOfs)/2
+		if cmp(key, s[m]) < 0 {
+			ofs = m
+		} else {
+			lastOfs = m + 1
+		}
+	}
+	return ofs
+}
@@ -0,0 +1,159 @@
+package sorting
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestStableSortProperties(t *testing.T) {
+	checkProperty(t, "SortStable", sortedLikeStdlib(SortStable[[]int]))
+	checkProperty(t, "TimSort", sortedLikeStdlib(TimSort[[]int]))
+	checkProperty(t, "TimSort(int8)", sortedLikeStdlib(TimSort[[]int8]))
+	checkProperty(t, "BottomUpMergeSort", sortedLikeStdlib(BottomUpMergeSort[[]int]))
+	checkProperty(t, "BottomUpMergeSort(float64)", sortedLikeStdlib(BottomUpMergeSort[[]float64]))
+	checkProperty(t, "SortStableFunc", sortedLikeStdlibFunc(SortStableFunc[[]string]))
+	checkProperty(t, "TimSortFunc", sortedLikeStdlibFunc(TimSortFunc[[]string]))
+	checkProperty(t, "BottomUpMergeSortFunc", sortedLikeStdlibFunc(BottomUpMergeSortFunc[[]string]))
+}
+
+// record是带有标记的记录：key是排序的依据，tag是记录在输入中的位置，
+// 稳定排序之后，key相同的记录应按tag从小到大排列。
+type record struct {
+	key, tag int
+}
+
+func compareKeys(a, b record) int {
+	return cmp.Compare(a.key, b.key)
+}
+
+// stableInputs返回用于检验稳定性的各种输入，key的取值很少，因此有大量相等的元素
+var stableInputs = map[string]func(n int) []int{
+	"random": func(n int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = rand.IntN(10)
+		}
+		return s
+	},
+	"ascendingRuns": func(n int) []int { //!!! 多个非递减的片段，TimSort需要归并它们
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i % 1000 / 100
+		}
+		return s
+	},
+	"descendingRuns": func(n int) []int { //!!! 非严格递减的片段不能整体反转，否则相等的元素会颠倒
+		s := make([]int, n)
+		for i := range s {
+			s[i] = 9 - i%1000/100
+		}
+		return s
+	},
+	"partiallySorted": func(n int) []int { //!!! 基本有序，只有少数元素被打乱，归并时会进入飞奔模式
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i * 10 / n
+		}
+		for k := 0; k < n/100; k++ {
+			i, j := rand.IntN(n), rand.IntN(n)
+			s[i], s[j] = s[j], s[i]
+		}
+		return s
+	},
+}
+
+func TestStability(t *testing.T) {
+	sorts := map[string]func([]record, func(a, b record) int){
+		"SortStableFunc":        SortStableFunc[[]record],
+		"TimSortFunc":           TimSortFunc[[]record],
+		"BottomUpMergeSortFunc": BottomUpMergeSortFunc[[]record],
+	}
+	for name, sort := range sorts {
+		for input, gen := range stableInputs {
+			for _, n := range []int{0, 1, 31, 32, 100, 1000, 10000} {
+				keys := gen(n)
+				records := make([]record, n)
+				for i, k := range keys {
+					records[i] = record{key: k, tag: i}
+				}
+				want := slices.Clone(records)
+				slices.SortStableFunc(want, compareKeys)
+				sort(records, compareKeys)
+				if !slices.Equal(records, want) {
+					t.Errorf("%s(%s, n=%d)不是稳定的", name, input, n)
+				}
+			}
+		}
+	}
+}
+
+// TimSort在大规模的随机、部分有序和由多个片段组成的输入上都应得到正确的结果
+func TestTimSortLarge(t *testing.T) {
+	inputs := map[string]func(n int) []int{
+		"random": func(n int) []int {
+			s := make([]int, n)
+			for i := range s {
+				s[i] = rand.Int()
+			}
+			return s
+		},
+		"interleavedRuns": func(n int) []int { //!!! 交替的递增和递减片段，长度各不相同
+			s := make([]int, 0, n)
+			for len(s) < n {
+				m := min(1+rand.IntN(500), n-len(s))
+				run := make([]int, m)
+				for i := range run {
+					run[i] = rand.IntN(n)
+				}
+				slices.Sort(run)
+				if rand.IntN(2) == 0 {
+					slices.Reverse(run)
+				}
+				s = append(s, run...)
+			}
+			return s
+		},
+	}
+	for name, gen := range adversarialInputs {
+		inputs[name] = gen
+	}
+	for name, gen := range inputs {
+		for _, n := range []int{1000, 100000} {
+			s := gen(n)
+			want := slices.Clone(s)
+			slices.Sort(want)
+			TimSort(s)
+			if !slices.Equal(s, want) {
+				t.Errorf("TimSort(%s, n=%d)的结果不正确", name, n)
+			}
+		}
+	}
+}
+
+// 对于已排序或严格逆序的输入，TimSort只需n-1次比较；两个有序片段的归并借助飞奔模式只需很少的比较
+func TestTimSortComparisons(t *testing.T) {
+	n := 1 << 16
+	for _, name := range []string{"sorted", "reversed"} {
+		s := adversarialInputs[name](n)
+		if got := countComparisons(t, s, TimSortFunc[[]int]); got != n-1 {
+			t.Errorf("%s: 比较次数为%d，应为%d", name, got, n-1)
+		}
+	}
+	//!!! 两个互不交错的有序片段：后一半整体小于前一半
+	s := make([]int, n)
+	for i := range s {
+		s[i] = (i + n/2) % n
+	}
+	if got, limit := countComparisons(t, s, TimSortFunc[[]int]), 2*n; got > limit {
+		t.Errorf("比较次数%d超过了上限%d", got, limit)
+	}
+	//!!! 随机输入的比较次数不超过n*log2(n)
+	for i := range s {
+		s[i] = rand.Int()
+	}
+	if got, limit := countComparisons(t, s, TimSortFunc[[]int]), nLogN(n); got > limit {
+		t.Errorf("比较次数%d超过了n*log(n)的上限%d", got, limit)
+	}
+}
@@ -0,0 +1,330 @@
+package basic
+
+import "iter"
+
+type StackPanic string
+
+const PopEmptyStack StackPanic = "空栈弹出"
+const TopEmptyStack StackPanic = "读取空栈"
+const PushNilValue StackPanic = "空值入栈"
+
+// Stack泛型接口提取了所有栈类型的共同性操作，及对操作的约定。
+type Stack[T any] interface {
+	//将一个元素压入栈，如果该元素的“零值”是nil,则不允许入栈，会抛出值为PushNilValue的 panic。
+	Push(item T)
+	//弹出栈中元素，如果栈已经为空，则会抛出值为PopEmptyStack的panic。
+	Pop() T
+	//读取栈顶端元素，如果栈已经为空，则会抛出值为TopEmptyStack的panic
+	Top() T
+	IsEmpty() bool
+}
+
+/*
+*
+SliceStack要求操作的元素的类型都是comparable的子类型，
+这是因为为了要防止将类型的“零值”入栈，因此，需要将操作的元素与
+"零值"进行比较，故而要求是comparable的子类型。
+*
+*/
+type SliceStack[T comparable] struct {
+	items  []T
+	policy NilPolicy
+}
+
+func getZero[T comparable]() T {
+	var zeroValue T
+	return zeroValue
+}
+
+// !!!默认策略（RejectZero）会静默丢弃“零值”，对于“零值”有意义的类型（比如int的0）可以用
+// !!!WithNilPolicy(AllowNil)创建栈以允许其入栈；需要知道元素是否被丢弃时使用PushErr。
+func (stack *SliceStack[T]) Push(item T) {
+	if stack.check(item) == nil {
+		stack.items = append(stack.items, item)
+	}
+}
+
+// check按照栈的nil策略检查入栈元素，SliceStack的默认策略是RejectZero。
+// !!! 由于T是comparable的，RejectZero策略可以直接与“零值”比较，无需使用反射。
+func (stack *SliceStack[T]) check(item T) error {
+	policy := stack.policy.orDefault(RejectZero)
+	if policy == RejectZero {
+		if item == getZero[T]() {
+			return ErrZeroValue
+		}
+		return nil
+	}
+	return checkValue(policy, item)
+}
+
+// !!! 这个实现认为当栈为空的时候，Pop操作返回类型的“零值”，这对于空值为非nil的类型来说不合理
+func (stack *SliceStack[T]) Pop() T {
+	var result T
+	length := len(stack.items)
+	if length > 0 {
+		result = stack.items[length-1]
+		stack.items = stack.items[:length-1]
+	}
+	return result
+}
+
+// !!! 这个实现认为当栈为空的时候，Top操作返回类型的“零值”，这对于空值为非nil的类型来说不合理
+func (stack SliceStack[T]) Top() T {
+	var result T
+	length := len(stack.items)
+	if length > 0 {
+		result = stack.items[length-1]
+	}
+	return result
+}
+func (stack SliceStack[T]) IsEmpty() bool {
+	return len(stack.items) == 0
+}
+
+// PushErr与Push相同，但在元素被nil策略拒绝时不会静默丢弃，而是返回ErrZeroValue或ErrNilValue。
+func (stack *SliceStack[T]) PushErr(item T) error {
+	if err := stack.check(item); err != nil {
+		return err
+	}
+	stack.items = append(stack.items, item)
+	return nil
+}
+
+// PopErr弹出栈顶元素，栈为空时返回ErrEmpty，而不是返回类型的“零值”。
+func (stack *SliceStack[T]) PopErr() (T, error) {
+	length := len(stack.items)
+	if length == 0 {
+		return getZero[T](), ErrEmpty
+	}
+	item := stack.items[length-1]
+	stack.items = stack.items[:length-1]
+	return item, nil
+}
+
+// TryPop弹出栈顶元素，栈为空时返回false。
+func (stack *SliceStack[T]) TryPop() (T, bool) {
+	item, err := stack.PopErr()
+	return item, err == nil
+}
+
+// TopErr读取栈顶元素，栈为空时返回ErrEmpty。
+func (stack SliceStack[T]) TopErr() (T, error) {
+	length := len(stack.items)
+	if length == 0 {
+		return getZero[T](), ErrEmpty
+	}
+	return stack.items[length-1], nil
+}
+
+// TryTop读取栈顶元素，栈为空时返回false。
+func (stack SliceStack[T]) TryTop() (T, bool) {
+	item, err := stack.TopErr()
+	return item, err == nil
+}
+
+// All返回从栈顶到栈底遍历栈中元素的迭代器（即元素弹出的顺序）
+func (stack *SliceStack[T]) All() iter.Seq[T] {
+	return backward(stack.items)
+}
+
+// Backward返回从栈底到栈顶遍历栈中元素的迭代器（即元素入栈的顺序）
+func (stack *SliceStack[T]) Backward() iter.Seq[T] {
+	return forward(stack.items)
+}
+
+// NewSliceStack创建一个空的SliceStack，可以用WithNilPolicy选项设置入栈元素的检查策略。
+func NewSliceStack[T comparable](opts ...Option) SliceStack[T] {
+	c := newConfig(opts)
+	return SliceStack[T]{policy: c.nilPolicy}
+}
+
+// SliceStackAny是以切片为存储结构的栈，可以存放任何类型的元素，默认不允许nil值入栈。
+type SliceStackAny[T any] struct {
+	items  []T
+	policy NilPolicy
+}
+
+func (stack *SliceStackAny[T]) Push(item T) {
+	if checkValue(stack.policy, item) != nil {
+		panic(PushNilValue)
+	} else {
+		stack.items = append(stack.items, item)
+	}
+}
+func (stack *SliceStackAny[T]) Pop() T {
+	length := len(stack.items)
+	if length == 0 {
+		panic(PopEmptyStack)
+	} else {
+		item := stack.items[length-1]
+		stack.items = stack.items[:length-1]
+		return item
+	}
+}
+func (stack *SliceStackAny[T]) Top() T {
+	length := len(stack.items)
+	if length == 0 {
+		panic(TopEmptyStack)
+	} else {
+		return stack.items[length-1]
+	}
+}
+
+func (stack SliceStackAny[T]) IsEmpty() bool {
+	return len(stack.items) == 0
+}
+
+// NewSliceStackAny创建一个空的SliceStackAny，可以用WithNilPolicy选项设置入栈元素的检查策略。
+func NewSliceStackAny[T any](opts ...Option) SliceStackAny[T] {
+	c := newConfig(opts)
+	return SliceStackAny[T]{policy: c.nilPolicy}
+}
+
+// All返回从栈顶到栈底遍历栈中元素的迭代器（即元素弹出的顺序）
+func (stack *SliceStackAny[T]) All() iter.Seq[T] {
+	return backward(stack.items)
+}
+
+// Backward返回从栈底到栈顶遍历栈中元素的迭代器（即元素入栈的顺序）
+func (stack *SliceStackAny[T]) Backward() iter.Seq[T] {
+	return forward(stack.items)
+}
+
+// PushErr与Push相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (stack *SliceStackAny[T]) PushErr(item T) error {
+	if err := checkValue(stack.policy, item); err != nil {
+		return err
+	}
+	stack.items = append(stack.items, item)
+	return nil
+}
+
+// PopErr与Pop相同，但在栈为空时返回ErrEmpty，而不是抛出panic。
+func (stack *SliceStackAny[T]) PopErr() (T, error) {
+	var zero T
+	length := len(stack.items)
+	if length == 0 {
+		return zero, ErrEmpty
+	}
+	item := stack.items[length-1]
+	stack.items = stack.items[:length-1]
+	return item, nil
+}
+
+// TryPop弹出栈顶元素，栈为空时返回false。
+func (stack *SliceStackAny[T]) TryPop() (T, bool) {
+	item, err := stack.PopErr()
+	return item, err == nil
+}
+
+// TopErr与Top相同，但在栈为空时返回ErrEmpty，而不是抛出panic。
+func (stack *SliceStackAny[T]) TopErr() (T, error) {
+	var zero T
+	length := len(stack.items)
+	if length == 0 {
+		return zero, ErrEmpty
+	}
+	return stack.items[length-1], nil
+}
+
+// TryTop读取栈顶元素，栈为空时返回false。
+func (stack *SliceStackAny[T]) TryTop() (T, bool) {
+	item, err := stack.TopErr()
+	return item, err == nil
+}
+
+// NodeStack是以单向链表节点（Node）为存储结构的栈，栈顶就是链表的第一个节点。
+type NodeStack[T any] struct {
+	first  *Node[T]
+	policy NilPolicy
+}
+
+func (stack *NodeStack[T]) Push(item T) {
+
+	if checkValue(stack.policy, item) != nil {
+		panic(PushNilValue)
+	} else {
+		nd := &Node[T]{value: item, next: nil}
+		nd.next = stack.first
+		stack.first = nd
+	}
+}
+func (stack *NodeStack[T]) Pop() T {
+	if stack.first == nil {
+		panic(PopEmptyStack)
+	} else {
+		nd := stack.first
+		stack.first = nd.next
+		return nd.value
+	}
+}
+func (stack *NodeStack[T]) Top() T {
+
+	if stack.first == nil {
+		panic(TopEmptyStack)
+	} else {
+		return stack.first.value
+	}
+}
+
+func (stack NodeStack[T]) IsEmpty() bool {
+	return stack.first == nil
+}
+
+// All返回从栈顶到栈底遍历栈中元素的迭代器（即元素弹出的顺序）
+func (stack *NodeStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for nd := stack.first; nd != nil; nd = nd.next {
+			if !yield(nd.value) {
+				return
+			}
+		}
+	}
+}
+
+// NewNodeStack创建一个空的NodeStack，可以用WithNilPolicy选项设置入栈元素的检查策略。
+func NewNodeStack[T any](opts ...Option) NodeStack[T] {
+	c := newConfig(opts)
+	return NodeStack[T]{policy: c.nilPolicy}
+}
+
+// PushErr与Push相同，但在元素被nil策略拒绝时返回ErrNilValue或ErrZeroValue，而不是抛出panic。
+func (stack *NodeStack[T]) PushErr(item T) error {
+	if err := checkValue(stack.policy, item); err != nil {
+		return err
+	}
+	stack.first = &Node[T]{value: item, next: stack.first}
+	return nil
+}
+
+// PopErr与Pop相同，但在栈为空时返回ErrEmpty，而不是抛出panic。
+func (stack *NodeStack[T]) PopErr() (T, error) {
+	var zero T
+	if stack.first == nil {
+		return zero, ErrEmpty
+	}
+	nd := stack.first
+	stack.first = nd.next
+	return nd.value, nil
+}
+
+// TryPop弹出栈顶元素，栈为空时返回false。
+func (stack *NodeStack[T]) TryPop() (T, bool) {
+	item, err := stack.PopErr()
+	return item, err == nil
+}
+
+// TopErr与Top相同，但在栈为空时返回ErrEmpty，而不是抛出panic。
+func (stack *NodeStack[T]) TopErr() (T, error) {
+	var zero T
+	if stack.first == nil {
+		return zero, ErrEmpty
+	}
+	return stack.first.value, nil
+}
+
+// TryTop读取栈顶元素，栈为空时返回false。
+func (stack *NodeStack[T]) TryTop() (T, bool) {
+	item, err := stack.TopErr()
+	return item, err == nil
+}
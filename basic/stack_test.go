@@ -7,75 +7,12 @@
 package basic
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 )
 
-type StackPanic string
-
-const PopEmptyStack StackPanic = "空栈弹出"
-const TopEmptyStack StackPanic = "读取空栈"
-const PushNilValue StackPanic = "空值入栈"
-
-// Stack泛型接口提取了所有栈类型的共同性操作，及对操作的约定。
-type Stack[T any] interface {
-	//将一个元素压入栈，如果该元素的“零值”是nil,则不允许入栈，会抛出值为PushNilValue的 panic。
-	Push(item T)
-	//弹出栈中元素，如果栈已经为空，则会抛出值为PopEmptyStack的panic。
-	Pop() T
-	//读取栈顶端元素，如果栈已经为空，则会抛出值为TopEmptyStack的panic
-	Top() T
-	IsEmpty() bool
-}
-
-/*
-*
-SliceStack要求操作的元素的类型都是comparable的子类型，
-这是因为为了要防止将类型的“零值”入栈，因此，需要将操作的元素与
-"零值"进行比较，故而要求是comparable的子类型。
-*
-*/
-type SliceStack[T comparable] struct {
-	items []T
-}
-
-func getZero[T comparable]() T {
-	var zeroValue T
-	return zeroValue
-}
-
-// !!!这个实现的阻止了“零值”的入栈，对于空值为非nil的类型来说不合理
-func (stack *SliceStack[T]) Push(item T) {
-	if item != getZero[T]() {
-		stack.items = append(stack.items, item)
-	}
-}
-
-// !!! 这个实现认为当栈为空的时候，Pop操作返回类型的“零值”，这对于空值为非nil的类型来说不合理
-func (stack *SliceStack[T]) Pop() T {
-	var result T
-	length := len(stack.items)
-	if length > 0 {
-		result = stack.items[length-1]
-		stack.items = stack.items[:length-1]
-	}
-	return result
-}
-
-// !!! 这个实现认为当栈为空的时候，Top操作返回类型的“零值”，这对于空值为非nil的类型来说不合理
-func (stack SliceStack[T]) Top() T {
-	var result T
-	length := len(stack.items)
-	if length > 0 {
-		result = stack.items[length-1]
-	}
-	return result
-}
-func (stack SliceStack[T]) IsEmpty() bool {
-	return len(stack.items) == 0
-}
-
 func TestSliceStack(t *testing.T) {
 	// Create a stack of names
 	var nameStack Stack[string]
@@ -129,42 +66,6 @@ func TestSliceStack(t *testing.T) {
 	}
 }
 
-type SliceStackAny[T any] struct {
-	items []T
-}
-
-func (stack *SliceStackAny[T]) Push(item T) {
-	if IsNil(item) {
-		panic(PushNilValue)
-	} else {
-		stack.items = append(stack.items, item)
-	}
-}
-func (stack *SliceStackAny[T]) Pop() T {
-	length := len(stack.items)
-	if length == 0 {
-		panic(PopEmptyStack)
-	} else {
-		item := stack.items[length-1]
-		stack.items = stack.items[:length-1]
-		return item
-	}
-}
-func (stack *SliceStackAny[T]) Top() T {
-	length := len(stack.items)
-	if length == 0 {
-		panic(TopEmptyStack)
-	} else {
-		return stack.items[length-1]
-	}
-}
-
-func (stack SliceStackAny[T]) IsEmpty() bool {
-	return len(stack.items) == 0
-}
-func NewSliceStackAny[T any]() SliceStackAny[T] {
-	return SliceStackAny[T]{}
-}
 func TestSliceStackAny(t *testing.T) {
 	var add func(a, b int) int = func(a, b int) int { return a + b }
 	var sub func(a, b int) int = func(a, b int) int { return a - b }
@@ -189,42 +90,6 @@ func TestSliceStackAny(t *testing.T) {
 	println(nameStack.Pop())
 }
 
-type NodeStack[T any] struct {
-	first *Node[T]
-}
-
-func (stack *NodeStack[T]) Push(item T) {
-
-	if IsNil(item) {
-		panic(PushNilValue)
-	} else {
-		nd := &Node[T]{value: item, next: nil}
-		nd.next = stack.first
-		stack.first = nd
-	}
-}
-func (stack *NodeStack[T]) Pop() T {
-	if stack.first == nil {
-		panic(PopEmptyStack)
-	} else {
-		nd := stack.first
-		stack.first = nd.next
-		return nd.value
-	}
-}
-func (stack *NodeStack[T]) Top() T {
-
-	if stack.first == nil {
-		panic(TopEmptyStack)
-	} else {
-		return stack.first.value
-	}
-}
-
-func (stack NodeStack[T]) IsEmpty() bool {
-	return stack.first == nil
-}
-
 func TestNodeStack(t *testing.T) {
 	/**
 	var add func(a, b int) int = func(a, b int) int { return a + b }
@@ -306,3 +171,50 @@ func TestSlice(t *testing.T) {
 	d1.id = 2
 	fmt.Printf("d1 is %v s is %v", d1, s)
 }
+
+func TestStackErrVariants(t *testing.T) {
+	stacks := map[string]interface {
+		Stack[int]
+		PushErr(item int) error
+		PopErr() (int, error)
+		TryPop() (int, bool)
+		TopErr() (int, error)
+		TryTop() (int, bool)
+	}{
+		"SliceStack":    &SliceStack[int]{},
+		"SliceStackAny": &SliceStackAny[int]{},
+		"NodeStack":     &NodeStack[int]{},
+	}
+	for name, stack := range stacks {
+		if _, err := stack.PopErr(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%s: 空栈PopErr应返回ErrEmpty，实际为%v", name, err)
+		}
+		if _, err := stack.TopErr(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%s: 空栈TopErr应返回ErrEmpty，实际为%v", name, err)
+		}
+		if _, ok := stack.TryPop(); ok {
+			t.Errorf("%s: 空栈TryPop应返回false", name)
+		}
+		if err := stack.PushErr(1); err != nil {
+			t.Errorf("%s: PushErr返回了错误%v", name, err)
+		}
+		stack.Push(2)
+		if top, ok := stack.TryTop(); !ok || top != 2 {
+			t.Errorf("%s: TryTop应返回(2,true)，实际为(%d,%v)", name, top, ok)
+		}
+		if item, err := stack.PopErr(); err != nil || item != 2 {
+			t.Errorf("%s: PopErr应返回(2,nil)，实际为(%d,%v)", name, item, err)
+		}
+		if item, ok := stack.TryPop(); !ok || item != 1 {
+			t.Errorf("%s: TryPop应返回(1,true)，实际为(%d,%v)", name, item, ok)
+		}
+	}
+	var nilStack SliceStackAny[*int]
+	if err := nilStack.PushErr(nil); !errors.Is(err, ErrNilValue) {
+		t.Errorf("nil值PushErr应返回ErrNilValue，实际为%v", err)
+	}
+	var zeroStack SliceStack[string]
+	if err := zeroStack.PushErr(""); !errors.Is(err, ErrZeroValue) {
+		t.Errorf("SliceStack的零值PushErr应返回ErrZeroValue，实际为%v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package stream
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"fmt"
+	"slices"
+
+	"datastructure/basic/myheap"
+)
+
+// SpaceSaving是Metwally等人提出的Space-Saving算法，用固定的m个计数器找出数据流中出现次数最多的元素（heavy hitters）。
+// !!! 每个计数器记录一个元素、它的计数count和误差error：
+// !!! 1. 元素已有计数器时，计数加1；
+// !!! 2. 否则，如果还有空闲的计数器，就为它分配一个计数为1的计数器；
+// !!! 3. 否则，把计数最小（设为min）的计数器让给新元素，计数变为min+1，误差为min，即新元素之前最多可能出现过min次。
+// !!! 因此count总是不小于真实的出现次数，count-error总是不大于真实的出现次数，并且min不超过N/m（N是流的长度），
+// !!! 所以任何出现次数超过N/m的元素一定在计数器中。
+// !!! 计数器保存在以计数为优先级的索引堆（myheap.IndexedHeap）中，找出并替换最小的计数器、增加计数都是O(log m)。
+// SpaceSaving必须通过NewSpaceSaving创建，或者由UnmarshalBinary恢复，并且不能被拷贝。
+type SpaceSaving[T comparable] struct {
+	capacity int
+	n        uint64 // 数据流的长度（所有计数之和）
+	floor    uint64 // 没有计数器的元素出现次数的上界，只在合并后才可能大于0
+	heap     *myheap.IndexedHeap[T, uint64]
+	counters map[T]*ssCounter[T]
+}
+
+type ssCounter[T any] struct {
+	handle *myheap.Handle[T, uint64]
+	err    uint64
+}
+
+// HeavyHitter是SpaceSaving的一个计数器：Item真实的出现次数在[Count-Error, Count]之间。
+type HeavyHitter[T any] struct {
+	Item  T
+	Count uint64
+	Error uint64
+}
+
+// NewSpaceSaving创建有capacity个计数器的SpaceSaving，capacity必须大于0。
+func NewSpaceSaving[T comparable](capacity int) *SpaceSaving[T] {
+	if capacity <= 0 {
+		panic("计数器的个数必须大于0")
+	}
+	return &SpaceSaving[T]{
+		capacity: capacity,
+		heap:     myheap.NewIndexed[T](cmp.Less[uint64]),
+		counters: make(map[T]*ssCounter[T], capacity),
+	}
+}
+
+// Add把x加入数据流，复杂度为O(log m)。
+func (ss *SpaceSaving[T]) Add(x T) {
+	ss.AddCount(x, 1)
+}
+
+// AddCount把count个x加入数据流，相当于调用count次Add(x)。
+func (ss *SpaceSaving[T]) AddCount(x T, count uint64) {
+	if count == 0 {
+		return
+	}
+	ss.n += count
+	if c, ok := ss.counters[x]; ok {
+		ss.heap.Update(c.handle, c.handle.Priority()+count)
+		return
+	}
+	if len(ss.counters) < ss.capacity {
+		//!!! 合并之后x可能在被丢弃的计数器中出现过，最多floor次
+		ss.counters[x] = &ssCounter[T]{handle: ss.heap.Push(x, ss.floor+count), err: ss.floor}
+		return
+	}
+	//!!! 把计数最小的计数器让给x
+	victim, minCount := ss.heap.Pop()
+	delete(ss.counters, victim)
+	ss.counters[x] = &ssCounter[T]{handle: ss.heap.Push(x, minCount+count), err: minCount}
+}
+
+// Count返回x的计数，即x出现次数的上界，以及误差。x没有计数器时，计数是所有计数器中最小的计数
+// （计数器未满时为0，合并之后为合并时确定的下限）。
+func (ss *SpaceSaving[T]) Count(x T) (count, err uint64) {
+	if c, ok := ss.counters[x]; ok {
+		return c.handle.Priority(), c.err
+	}
+	m := ss.minCount()
+	return m, m
+}
+
+// minCount返回计数器已满时最小的计数，计数器未满时返回floor：没有计数器的元素的出现次数不会超过它。
+func (ss *SpaceSaving[T]) minCount() uint64 {
+	if len(ss.counters) < ss.capacity {
+		return ss.floor
+	}
+	_, m := ss.heap.Peek()
+	return max(m, ss.floor)
+}
+
+// N返回数据流的长度
+func (ss *SpaceSaving[T]) N() uint64 {
+	return ss.n
+}
+
+// TopK返回计数最大的k个计数器，按计数从大到小排列，计数相同时误差小的排在前面。
+func (ss *SpaceSaving[T]) TopK(k int) []HeavyHitter[T] {
+	hitters := ss.all()
+	return hitters[:min(k, len(hitters))]
+}
+
+// HeavyHitters返回出现次数可能超过phi*N的所有元素（0 < phi < 1），按计数从大到小排列。
+// !!! 只要phi*N不小于N/m（合并过容量不同的摘要时还要不小于floor），出现次数超过phi*N的元素都在结果中；结果中Count-Error超过phi*N的元素一定是真正的heavy hitter。
+func (ss *SpaceSaving[T]) HeavyHitters(phi float64) []HeavyHitter[T] {
+	threshold := phi * float64(ss.n)
+	hitters := ss.all()
+	i := 0
+	for i < len(hitters) && float64(hitters[i].Count) > threshold {
+		i++
+	}
+	return hitters[:i]
+}
+
+// all返回所有的计数器，按计数从大到小排列
+func (ss *SpaceSaving[T]) all() []HeavyHitter[T] {
+	hitters := make([]HeavyHitter[T], 0, len(ss.counters))
+	for x, c := range ss.counters {
+		hitters = append(hitters, HeavyHitter[T]{Item: x, Count: c.handle.Priority(), Error: c.err})
+	}
+	slices.SortFunc(hitters, func(a, b HeavyHitter[T]) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Error, b.Error)
+	})
+	return hitters
+}
+
+// Merge把other合并到ss中，other不变，合并后ss的计数器个数不变。
+// !!! 这里采用Agarwal等人在“Mergeable Summaries”中给出的合并方法：对于两个摘要中出现的每个元素，
+// !!! 它在某个摘要中没有计数器时，就按那个摘要的minCount计算（这是它在那部分数据中出现次数的上界），
+// !!! 计数与误差分别相加，再保留计数最大的m个。合并后Count仍是上界，Count-Error仍是下界。
+// !!! 两个摘要中都没有计数器的元素，出现次数不超过min1+min2；被丢弃的计数器中的元素不超过被丢弃的最大计数。
+// !!! 合并后的计数器可能未满（例如两个摘要的容量不同），这时这两个上界之中较大的一个记为floor，
+// !!! 由Count返回，也作为此后新元素计数的起点，否则这些元素的出现次数会被当作0。
+func (ss *SpaceSaving[T]) Merge(other *SpaceSaving[T]) {
+	min1, min2 := ss.minCount(), other.minCount()
+	merged := make(map[T]HeavyHitter[T], len(ss.counters)+len(other.counters))
+	for x, c := range ss.counters {
+		c2, e2 := min2, min2
+		if oc, ok := other.counters[x]; ok {
+			c2, e2 = oc.handle.Priority(), oc.err
+		}
+		merged[x] = HeavyHitter[T]{Item: x, Count: c.handle.Priority() + c2, Error: c.err + e2}
+	}
+	for x, oc := range other.counters {
+		if _, ok := ss.counters[x]; !ok {
+			merged[x] = HeavyHitter[T]{Item: x, Count: oc.handle.Priority() + min1, Error: oc.err + min1}
+		}
+	}
+	hitters := make([]HeavyHitter[T], 0, len(merged))
+	for _, h := range merged {
+		hitters = append(hitters, h)
+	}
+	slices.SortFunc(hitters, func(a, b HeavyHitter[T]) int { return cmp.Compare(b.Count, a.Count) })
+	floor := min1 + min2
+	if len(hitters) > ss.capacity {
+		floor = max(floor, hitters[ss.capacity].Count)
+	}
+	ss.reset(hitters[:min(ss.capacity, len(hitters))], ss.n+other.n, floor)
+}
+
+// reset用给定的计数器、数据流长度和floor重建ss
+func (ss *SpaceSaving[T]) reset(hitters []HeavyHitter[T], n, floor uint64) {
+	ss.n = n
+	ss.floor = floor
+	ss.heap = myheap.NewIndexed[T](cmp.Less[uint64])
+	ss.counters = make(map[T]*ssCounter[T], ss.capacity)
+	for _, h := range hitters {
+		ss.counters[h.Item] = &ssCounter[T]{handle: ss.heap.Push(h.Item, h.Count), err: h.Error}
+	}
+}
+
+// spaceSavingState是SpaceSaving序列化的内容
+type spaceSavingState[T any] struct {
+	Capacity int
+	N        uint64
+	Floor    uint64
+	Counters []HeavyHitter[T]
+}
+
+// MarshalBinary用encoding/gob序列化ss，T必须能被gob编码。
+func (ss *SpaceSaving[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	state := spaceSavingState[T]{Capacity: ss.capacity, N: ss.n, Floor: ss.floor, Counters: ss.all()}
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, fmt.Errorf("stream: 序列化SpaceSaving: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary从MarshalBinary的结果恢复ss的全部内容，ss可以是零值。
+func (ss *SpaceSaving[T]) UnmarshalBinary(data []byte) error {
+	var state spaceSavingState[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state); err != nil {
+		return fmt.Errorf("stream: 反序列化SpaceSaving: %w", err)
+	}
+	if state.Capacity <= 0 || len(state.Counters) > state.Capacity {
+		return fmt.Errorf("stream: 反序列化SpaceSaving: 计数器的个数为%d，容量为%d", len(state.Counters), state.Capacity)
+	}
+	ss.capacity = state.Capacity
+	ss.reset(state.Counters, state.N, state.Floor)
+	return nil
+}
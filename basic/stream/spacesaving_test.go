@@ -0,0 +1,134 @@
+package stream
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// zipfStream返回服从Zipf分布的数据流及每个元素真实的出现次数
+func zipfStream(n int, seed uint64) ([]uint64, map[uint64]uint64) {
+	r := rand.New(rand.NewPCG(seed, seed))
+	zipf := rand.NewZipf(r, 1.2, 1, 10000)
+	stream := make([]uint64, n)
+	exact := make(map[uint64]uint64)
+	for i := range stream {
+		stream[i] = zipf.Uint64()
+		exact[stream[i]]++
+	}
+	return stream, exact
+}
+
+// checkBounds检查每个计数器都满足Count-Error <= 真实次数 <= Count
+func checkBounds(t *testing.T, ss *SpaceSaving[uint64], exact map[uint64]uint64) {
+	t.Helper()
+	for _, h := range ss.TopK(ss.capacity) {
+		if real := exact[h.Item]; real > h.Count || real < h.Count-h.Error {
+			t.Errorf("%d: 真实次数%d不在[%d, %d]之间", h.Item, real, h.Count-h.Error, h.Count)
+		}
+	}
+}
+
+func TestSpaceSaving(t *testing.T) {
+	const n, m = 100000, 100
+	stream, exact := zipfStream(n, 1)
+	ss := NewSpaceSaving[uint64](m)
+	Consume(ss, slices.Values(stream))
+	if ss.N() != n {
+		t.Errorf("N = %d，应为%d", ss.N(), n)
+	}
+	checkBounds(t, ss, exact)
+	//!!! 出现次数超过N/m的元素都必须在结果中
+	hitters := ss.HeavyHitters(1.0 / m)
+	for x, c := range exact {
+		if c > n/m && !slices.ContainsFunc(hitters, func(h HeavyHitter[uint64]) bool { return h.Item == x }) {
+			t.Errorf("出现%d次的元素%d不在heavy hitters中", c, x)
+		}
+	}
+	//!!! Zipf分布中最常见的元素是0，1，2……
+	for i, h := range ss.TopK(3) {
+		if h.Item != uint64(i) {
+			t.Errorf("TopK(3)[%d] = %d，应为%d", i, h.Item, i)
+		}
+	}
+	if count, err := ss.Count(0); count < exact[0] || count-err > exact[0] {
+		t.Errorf("Count(0) = (%d, %d)，真实次数为%d", count, err, exact[0])
+	}
+}
+
+func TestSpaceSavingMerge(t *testing.T) {
+	const m = 50
+	merged := NewSpaceSaving[uint64](m)
+	exact := make(map[uint64]uint64)
+	for shard := range uint64(4) {
+		stream, shardExact := zipfStream(20000, shard+10)
+		for x, c := range shardExact {
+			exact[x] += c
+		}
+		ss := NewSpaceSaving[uint64](m)
+		Consume(ss, slices.Values(stream))
+		merged.Merge(ss)
+	}
+	if merged.N() != 80000 {
+		t.Errorf("N = %d，应为80000", merged.N())
+	}
+	checkBounds(t, merged, exact)
+	if top := merged.TopK(1); top[0].Item != 0 {
+		t.Errorf("合并后最常见的元素是%d，应为0", top[0].Item)
+	}
+}
+
+func TestSpaceSavingMarshal(t *testing.T) {
+	ss := NewSpaceSaving[string](3)
+	for _, x := range []string{"a", "b", "a", "c", "a", "d", "b"} {
+		ss.Add(x)
+	}
+	ss.AddCount("e", 5)
+	data, err := ss.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored SpaceSaving[string]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(restored.TopK(3), ss.TopK(3)) || restored.N() != ss.N() {
+		t.Errorf("恢复后的计数器%v与原来的%v不同", restored.TopK(3), ss.TopK(3))
+	}
+	restored.Add("e")
+	if count, _ := restored.Count("e"); count != 8 { //!!! e替换了计数为2的计数器：2+5，再加1
+		t.Errorf("Count(e) = %d，应为8", count)
+	}
+}
+
+func TestSpaceSavingMergeDifferentCapacities(t *testing.T) {
+	a := NewSpaceSaving[string](10)
+	Consume(a, slices.Values([]string{"x", "y", "x"}))
+	b := NewSpaceSaving[string](3)
+	//!!! r在b中被z挤掉，合并后a的计数器仍未满
+	Consume(b, slices.Values([]string{"p", "p", "q", "q", "r", "r", "z", "z", "z", "z", "z"}))
+	exact := map[string]uint64{"x": 2, "y": 1, "p": 2, "q": 2, "r": 2, "z": 5}
+	a.Merge(b)
+	for x, real := range exact {
+		if count, err := a.Count(x); count < real || count-err > real {
+			t.Errorf("Count(%s) = (%d, %d)，真实次数为%d", x, count, err, real)
+		}
+	}
+	//!!! 合并之后新出现的r的计数也必须是上界
+	a.Add("r")
+	if count, err := a.Count("r"); count < 3 || count-err > 3 {
+		t.Errorf("Count(r) = (%d, %d)，真实次数为3", count, err)
+	}
+	//!!! floor经过序列化后保留
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored SpaceSaving[string]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := restored.Count("w"); count != a.minCount() || count == 0 {
+		t.Errorf("恢复后没有计数器的元素的计数为%d，应为%d", count, a.minCount())
+	}
+}
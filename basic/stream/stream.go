@@ -0,0 +1,32 @@
+// stream包提供了在无界的数据流上计算统计量的流式算子（也称为“摘要”或sketch）：
+// !!! TopK用一个有界的最小堆保存最大的k个元素；SpaceSaving在固定的空间内找出出现次数最多的元素（heavy hitters）；
+// !!! TDigest在固定的空间内近似地计算分位数，例如P50、P99。
+// !!! 与selection包中需要把全部数据放在切片中的算法不同，这些算子每次只处理一个元素（Add），
+// !!! 所占的空间与流的长度无关，可以通过Consume消费iter.Seq[T]，或者通过ConsumeIterator消费basic.Iterator[T]。
+// !!! 每种算子都可以合并（Merge）：把数据分片到多个节点上各自计算，再把各个节点的结果合并为整体的结果；
+// !!! 每种算子都实现了encoding.BinaryMarshaler和encoding.BinaryUnmarshaler（基于encoding/gob），以便在节点之间传输。
+// !!! 此外，SlidingWindow只在最近的数据（按个数或按时间）上计算最大值、最小值、和、均值以及任意的幺半群聚合。
+package stream
+
+import (
+	"iter"
+
+	"datastructure/basic"
+)
+
+// Sketch是流式算子的公共接口
+type Sketch[T any] interface {
+	Add(x T)
+}
+
+// Consume把seq中的所有元素依次加入s
+func Consume[T any](s Sketch[T], seq iter.Seq[T]) {
+	for x := range seq {
+		s.Add(x)
+	}
+}
+
+// ConsumeIterator把迭代器it中剩余的所有元素依次加入s
+func ConsumeIterator[T any](s Sketch[T], it basic.Iterator[T]) {
+	Consume(s, basic.Seq(it))
+}
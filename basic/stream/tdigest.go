@@ -0,0 +1,222 @@
+package stream
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// TDigest是Dunning提出的t-digest，在固定的空间内近似地计算数据流的分位数。
+// !!! t-digest把数据聚合为若干个质心（centroid），每个质心记录一组相邻数据的均值和个数（权重）。
+// !!! 位于分布两端（分位数接近0或1）的质心只允许很小的权重，中间的质心可以有较大的权重，
+// !!! 这由刻度函数k(q) = δ/(2π) * asin(2q-1)控制：一个质心所覆盖的分位数区间[q1, q2]必须满足k(q2)-k(q1) <= 1，
+// !!! 因此质心的个数不超过δ（compression），而P99、P999这样的极端分位数仍然很精确。
+// !!! 新加入的数据先放在缓冲区中，缓冲区满时与已有的质心一起排序，再从左向右贪心地合并相邻的质心（“merging t-digest”）。
+// !!! 两个TDigest合并时只需把对方的质心加入缓冲区，再做一次同样的压缩。
+// TDigest必须通过NewTDigest创建，或者由UnmarshalBinary恢复。
+type TDigest struct {
+	compression float64
+	centroids   []centroid // 按均值排序的质心
+	buffer      []centroid // 尚未压缩的数据，每个数据是权重为1的质心
+	count       float64    // 所有数据的总权重
+	min, max    float64
+}
+
+// centroid是t-digest的质心
+type centroid struct {
+	Mean, Weight float64
+}
+
+// NewTDigest创建一个TDigest，compression（即δ）越大越精确，占用的空间也越大，通常取100，必须不小于10。
+func NewTDigest(compression float64) *TDigest {
+	if !(compression >= 10) {
+		panic("compression必须不小于10")
+	}
+	return &TDigest{compression: compression, min: math.Inf(1), max: math.Inf(-1)}
+}
+
+// bufferSize返回缓冲区的容量
+func (td *TDigest) bufferSize() int {
+	return int(5 * td.compression)
+}
+
+// Add把x加入数据流，NaN被忽略。
+func (td *TDigest) Add(x float64) {
+	td.AddWeighted(x, 1)
+}
+
+// AddWeighted把权重为w的x加入数据流，w必须大于0，NaN被忽略。
+func (td *TDigest) AddWeighted(x, w float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	if !(w > 0) {
+		panic("权重必须大于0")
+	}
+	td.buffer = append(td.buffer, centroid{x, w})
+	td.count += w
+	td.min = min(td.min, x)
+	td.max = max(td.max, x)
+	if len(td.buffer) >= td.bufferSize() {
+		td.flush()
+	}
+}
+
+// scale是刻度函数k(q)
+func (td *TDigest) scale(q float64) float64 {
+	return td.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// scaleInverse是刻度函数的反函数
+func (td *TDigest) scaleInverse(k float64) float64 {
+	return (math.Sin(k*2*math.Pi/td.compression) + 1) / 2
+}
+
+// flush把缓冲区中的数据与已有的质心一起排序并压缩
+func (td *TDigest) flush() {
+	if len(td.buffer) == 0 {
+		return
+	}
+	all := append(td.centroids, td.buffer...)
+	slices.SortFunc(all, func(a, b centroid) int { return cmp.Compare(a.Mean, b.Mean) })
+	td.buffer = td.buffer[:0]
+
+	merged := all[:1]  //!!! 就地合并：merged的长度不会超过已经处理的质心个数
+	weightSoFar := 0.0 // merged中除最后一个质心之外的总权重
+	limit := td.count * td.scaleInverse(td.scale(0)+1)
+	for _, c := range all[1:] {
+		cur := &merged[len(merged)-1]
+		if weightSoFar+cur.Weight+c.Weight <= limit {
+			//!!! 合并后的质心覆盖的分位数区间仍然满足k(q2)-k(q1) <= 1
+			cur.Weight += c.Weight
+			cur.Mean += (c.Mean - cur.Mean) * c.Weight / cur.Weight
+			continue
+		}
+		weightSoFar += cur.Weight
+		limit = td.count * td.scaleInverse(td.scale(weightSoFar/td.count)+1)
+		merged = append(merged, c)
+	}
+	td.centroids = merged
+}
+
+// Quantile返回q分位数（0 <= q <= 1）的近似值，数据流为空时返回NaN。
+// !!! 每个质心的均值被看作位于其权重中点处的分位数，两个相邻质心之间做线性插值，两端分别以最小值和最大值为界。
+func (td *TDigest) Quantile(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("分位数必须在0到1之间")
+	}
+	td.flush()
+	if len(td.centroids) == 0 {
+		return math.NaN()
+	}
+	target := q * td.count
+	prevMean, prevCenter := td.min, 0.0
+	cumulative := 0.0
+	for _, c := range td.centroids {
+		center := cumulative + c.Weight/2
+		if target < center {
+			return interpolate(prevMean, c.Mean, prevCenter, center, target)
+		}
+		prevMean, prevCenter = c.Mean, center
+		cumulative += c.Weight
+	}
+	return interpolate(prevMean, td.max, prevCenter, td.count, target)
+}
+
+// interpolate在(x0, y0)与(x1, y1)之间对x做线性插值，注意这里y是值、x是累计权重
+func interpolate(y0, y1, x0, x1, x float64) float64 {
+	if x1 <= x0 {
+		return y1
+	}
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
+
+// CDF返回不大于x的数据所占比例的近似值，数据流为空时返回NaN。
+func (td *TDigest) CDF(x float64) float64 {
+	td.flush()
+	switch {
+	case len(td.centroids) == 0:
+		return math.NaN()
+	case x < td.min:
+		return 0
+	case x >= td.max:
+		return 1
+	}
+	prevMean, prevCenter := td.min, 0.0
+	cumulative := 0.0
+	for _, c := range td.centroids {
+		center := cumulative + c.Weight/2
+		if x < c.Mean {
+			return interpolate(prevCenter, center, prevMean, c.Mean, x) / td.count
+		}
+		prevMean, prevCenter = c.Mean, center
+		cumulative += c.Weight
+	}
+	return interpolate(prevCenter, td.count, prevMean, td.max, x) / td.count
+}
+
+// Count返回数据流的总权重
+func (td *TDigest) Count() float64 {
+	return td.count
+}
+
+// Min返回数据流中的最小值，数据流为空时返回+Inf
+func (td *TDigest) Min() float64 {
+	return td.min
+}
+
+// Max返回数据流中的最大值，数据流为空时返回-Inf
+func (td *TDigest) Max() float64 {
+	return td.max
+}
+
+// Merge把other合并到td中，other中尚未压缩的数据会被压缩，但内容不变。
+func (td *TDigest) Merge(other *TDigest) {
+	other.flush()
+	td.buffer = append(td.buffer, other.centroids...)
+	td.count += other.count
+	td.min = min(td.min, other.min)
+	td.max = max(td.max, other.max)
+	td.flush()
+}
+
+// tDigestState是TDigest序列化的内容
+type tDigestState struct {
+	Compression float64
+	Centroids   []centroid
+	Count       float64
+	Min, Max    float64
+}
+
+// MarshalBinary用encoding/gob序列化td，缓冲区中的数据会先被压缩。
+func (td *TDigest) MarshalBinary() ([]byte, error) {
+	td.flush()
+	var buf bytes.Buffer
+	state := tDigestState{Compression: td.compression, Centroids: td.centroids, Count: td.count, Min: td.min, Max: td.max}
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, fmt.Errorf("stream: 序列化TDigest: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary从MarshalBinary的结果恢复td的全部内容，td可以是零值。
+func (td *TDigest) UnmarshalBinary(data []byte) error {
+	var state tDigestState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state); err != nil {
+		return fmt.Errorf("stream: 反序列化TDigest: %w", err)
+	}
+	if !(state.Compression >= 10) {
+		return fmt.Errorf("stream: 反序列化TDigest: compression=%v", state.Compression)
+	}
+	*td = TDigest{
+		compression: state.Compression,
+		centroids:   state.Centroids,
+		count:       state.Count,
+		min:         state.Min,
+		max:         state.Max,
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package stream
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"datastructure/basic/selection"
+)
+
+// checkQuantiles比较td的分位数与精确的分位数，误差以“排名”衡量：近似值在排序后的数据中的位置与q之差
+func checkQuantiles(t *testing.T, td *TDigest, data []float64) {
+	t.Helper()
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+	for _, q := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+		got := td.Quantile(q)
+		rank, _ := slices.BinarySearch(sorted, got)
+		//!!! t-digest在两端的误差远小于中间，这里按q(1-q)放宽
+		if errRank, limit := math.Abs(float64(rank)/float64(len(sorted))-q), 0.002+0.02*q*(1-q); errRank > limit {
+			t.Errorf("Quantile(%v) = %v，精确值为%v，排名误差%.4f超过%.4f",
+				q, got, selection.Quantile(data, q), errRank, limit)
+		}
+	}
+	if td.Quantile(0) != sorted[0] || td.Quantile(1) != sorted[len(sorted)-1] {
+		t.Errorf("Quantile(0)和Quantile(1)应为最小值和最大值")
+	}
+}
+
+func TestTDigest(t *testing.T) {
+	distributions := map[string]func() float64{
+		"uniform":     rand.Float64,
+		"normal":      rand.NormFloat64,
+		"exponential": rand.ExpFloat64,
+	}
+	for name, gen := range distributions {
+		data := make([]float64, 100000)
+		for i := range data {
+			data[i] = gen()
+		}
+		td := NewTDigest(100)
+		Consume(td, slices.Values(data))
+		t.Run(name, func(t *testing.T) { checkQuantiles(t, td, data) })
+		if td.Count() != float64(len(data)) {
+			t.Errorf("Count = %v，应为%d", td.Count(), len(data))
+		}
+		if n := len(td.centroids); n > 100 {
+			t.Errorf("质心的个数%d超过了compression", n)
+		}
+		if cdf := td.CDF(td.Quantile(0.3)); math.Abs(cdf-0.3) > 0.01 {
+			t.Errorf("CDF(Quantile(0.3)) = %v", cdf)
+		}
+	}
+}
+
+func TestTDigestMergeAndMarshal(t *testing.T) {
+	data := make([]float64, 0, 80000)
+	merged := NewTDigest(100)
+	for shard := range 4 {
+		td := NewTDigest(100)
+		for range 20000 {
+			x := rand.NormFloat64() + float64(shard) //!!! 每个分片的分布不同
+			data = append(data, x)
+			td.Add(x)
+		}
+		//!!! 经过序列化传输后再合并
+		bytes, err := td.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var received TDigest
+		if err := received.UnmarshalBinary(bytes); err != nil {
+			t.Fatal(err)
+		}
+		merged.Merge(&received)
+	}
+	checkQuantiles(t, merged, data)
+
+	empty := NewTDigest(50)
+	if !math.IsNaN(empty.Quantile(0.5)) || !math.IsNaN(empty.CDF(0)) {
+		t.Errorf("空的TDigest应返回NaN")
+	}
+	empty.Add(math.NaN())
+	empty.Add(3)
+	if empty.Quantile(0.5) != 3 || empty.Count() != 1 {
+		t.Errorf("只有一个数据时分位数应为该数据")
+	}
+}
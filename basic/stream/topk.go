@@ -0,0 +1,94 @@
+package stream
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"fmt"
+	"slices"
+
+	"datastructure/basic/myheap"
+)
+
+// TopK保存数据流中最大的k个元素。
+// !!! 内部是一个容量为k的最小堆，堆顶是已保留的元素中最小的一个：新元素大于堆顶时替换堆顶并下沉，否则直接丢弃，
+// !!! 因此每个元素的处理时间为O(log k)，空间为O(k)。两个TopK合并后仍然是二者输入之并的最大的k个元素。
+// TopK必须通过NewTopK或NewTopKFunc创建，并且不能被拷贝。
+type TopK[T any] struct {
+	k    int
+	cmp  func(a, b T) int
+	heap *myheap.Heap[T]
+}
+
+// NewTopK创建保存最大的k个元素的TopK，k必须大于0。
+func NewTopK[T cmp.Ordered](k int) *TopK[T] {
+	return NewTopKFunc(k, cmp.Compare[T])
+}
+
+// NewTopKFunc与NewTopK相同，但使用cmp函数比较元素
+func NewTopKFunc[T any](k int, cmp func(a, b T) int) *TopK[T] {
+	if k <= 0 {
+		panic("k必须大于0")
+	}
+	return &TopK[T]{k: k, cmp: cmp, heap: myheap.New(func(a, b T) bool { return cmp(a, b) < 0 })}
+}
+
+// Add把x加入数据流，复杂度为O(log k)。
+func (tk *TopK[T]) Add(x T) {
+	if tk.heap.Len() < tk.k {
+		tk.heap.Push(x)
+	} else if tk.cmp(x, tk.heap.Peek()) > 0 {
+		tk.heap.Set(0, x)
+	}
+}
+
+// Items返回目前最大的k个元素（元素不足k个时返回全部元素），按从大到小的顺序排列。
+func (tk *TopK[T]) Items() []T {
+	items := make([]T, tk.heap.Len())
+	for i := range items {
+		items[i] = tk.heap.At(i)
+	}
+	slices.SortFunc(items, func(a, b T) int { return tk.cmp(b, a) })
+	return items
+}
+
+// Len返回目前保留的元素个数，不超过k
+func (tk *TopK[T]) Len() int {
+	return tk.heap.Len()
+}
+
+// Merge把other保留的元素合并到tk中，other不变。
+func (tk *TopK[T]) Merge(other *TopK[T]) {
+	for i := 0; i < other.heap.Len(); i++ {
+		tk.Add(other.heap.At(i))
+	}
+}
+
+// topKState是TopK序列化的内容，比较函数无法序列化
+type topKState[T any] struct {
+	K     int
+	Items []T
+}
+
+// MarshalBinary用encoding/gob序列化tk，T必须能被gob编码。
+func (tk *TopK[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(topKState[T]{K: tk.k, Items: tk.Items()}); err != nil {
+		return nil, fmt.Errorf("stream: 序列化TopK: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary从MarshalBinary的结果恢复tk的内容（包括k），tk必须已经由NewTopK或NewTopKFunc创建，以提供比较函数。
+func (tk *TopK[T]) UnmarshalBinary(data []byte) error {
+	var state topKState[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state); err != nil {
+		return fmt.Errorf("stream: 反序列化TopK: %w", err)
+	}
+	if state.K <= 0 || len(state.Items) > state.K {
+		return fmt.Errorf("stream: 反序列化TopK: k=%d, 元素个数为%d", state.K, len(state.Items))
+	}
+	tk.k = state.K
+	tk.heap = myheap.NewFromSlice(state.Items, func(a, b T) bool { return tk.cmp(a, b) < 0 })
+	return nil
+}
@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"datastructure/basic"
+)
+
+func TestTopK(t *testing.T) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = rand.IntN(1000000)
+	}
+	want := slices.Clone(data)
+	slices.SortFunc(want, func(a, b int) int { return cmp.Compare(b, a) })
+
+	tk := NewTopK[int](10)
+	Consume(tk, slices.Values(data))
+	if got := tk.Items(); !slices.Equal(got, want[:10]) {
+		t.Errorf("TopK = %v，应为%v", got, want[:10])
+	}
+
+	//!!! 分片计算后合并，结果与整体计算相同
+	shards := make([]*TopK[int], 4)
+	for i := range shards {
+		shards[i] = NewTopK[int](10)
+		Consume(shards[i], slices.Values(data[i*2500:(i+1)*2500]))
+	}
+	for _, shard := range shards[1:] {
+		shards[0].Merge(shard)
+	}
+	if got := shards[0].Items(); !slices.Equal(got, want[:10]) {
+		t.Errorf("合并后的TopK = %v，应为%v", got, want[:10])
+	}
+
+	//!!! 元素不足k个
+	small := NewTopK[int](5)
+	Consume(small, slices.Values([]int{3, 1, 2}))
+	if got := small.Items(); !slices.Equal(got, []int{3, 2, 1}) || small.Len() != 3 {
+		t.Errorf("TopK = %v，应为[3 2 1]", got)
+	}
+}
+
+func TestTopKFuncAndIterator(t *testing.T) {
+	//!!! 按长度选出最短的2个字符串，数据来自basic.Iterator
+	tk := NewTopKFunc(2, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	it, stop := basic.FromSeq(slices.Values(strings.Fields("alpha be c delta epsilon")))
+	defer stop()
+	ConsumeIterator(tk, it)
+	if got := tk.Items(); !slices.Equal(got, []string{"c", "be"}) {
+		t.Errorf("TopK = %v，应为[c be]", got)
+	}
+}
+
+func TestTopKMarshal(t *testing.T) {
+	tk := NewTopK[string](3)
+	Consume(tk, slices.Values(strings.Fields("d a f b e c")))
+	data, err := tk.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := NewTopK[string](1) //!!! k由序列化的内容恢复
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	restored.Add("z")
+	if got := restored.Items(); !slices.Equal(got, []string{"z", "f", "e"}) {
+		t.Errorf("恢复后的TopK = %v，应为[z f e]", got)
+	}
+	if err := restored.UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Errorf("反序列化无效的数据应返回错误")
+	}
+}
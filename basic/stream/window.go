@@ -0,0 +1,285 @@
+package stream
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"time"
+
+	"datastructure/basic"
+	"datastructure/basic/sorting"
+)
+
+// Number是SlidingWindow的元素类型的约束：所有整数和浮点数类型
+type Number interface {
+	sorting.Integer | ~float32 | ~float64
+}
+
+// SlidingWindow在数据流上维护一个滑动窗口，并在均摊O(1)的时间内给出窗口的最大值、最小值、和与均值。
+// !!! 它是basic包中MaxSubarrayUsingDeque的推广：最大值（最小值）用一个“淘汰式”的单调双向队列维护，
+// !!! 新元素从队尾淘汰所有不比它大的元素后入队，队头就是窗口的最大值，队头元素离开窗口时从队头移除，
+// !!! 每个元素最多入队、出队各一次。和与其他满足结合律的聚合（见Monoid与Aggregate）用“双栈”技巧维护。
+// !!! 窗口有两种：
+// !!! 1. 按个数（NewCountWindow）：窗口保存最近的size个元素；
+// !!! 2. 按时间（NewTimeWindow）：窗口保存时间戳在(now-span, now]之内的元素，now是最近一个元素的时间戳，
+// !!!    没有新元素时可以调用Expire(now)按给定的时间淘汰过期的元素。
+// !!! 与其他算子不同，滑动窗口只关心最近的数据，因此不能合并，也不能序列化。
+// SlidingWindow必须通过NewCountWindow或NewTimeWindow创建，并且不是并发安全的。
+type SlidingWindow[T Number] struct {
+	size    int           // 按个数的窗口大小，0表示不按个数淘汰
+	span    time.Duration // 按时间的窗口跨度，0表示不按时间淘汰
+	seq     uint64        // 下一个元素的序号
+	entries basic.RingDeque[windowEntry[T]]
+
+	max, min  monotonicQueue[T]
+	sum       *Aggregate[T, T]
+	observers []windowObserver[T] // 包括sum在内的所有Aggregate
+	onEvict   func(x T)
+}
+
+// windowEntry是窗口中的一个元素
+type windowEntry[T any] struct {
+	seq   uint64
+	value T
+	at    time.Time
+}
+
+// windowObserver在元素进入和离开窗口时得到通知，元素总是按进入的顺序离开
+type windowObserver[T any] interface {
+	push(x T)
+	evict()
+}
+
+// NewCountWindow创建保存最近size个元素的滑动窗口，size必须大于0。
+func NewCountWindow[T Number](size int) *SlidingWindow[T] {
+	if size <= 0 {
+		panic("窗口大小必须大于0")
+	}
+	return newSlidingWindow[T](size, 0)
+}
+
+// NewTimeWindow创建保存最近span时间内的元素的滑动窗口，span必须大于0。
+func NewTimeWindow[T Number](span time.Duration) *SlidingWindow[T] {
+	if span <= 0 {
+		panic("时间窗口必须大于0")
+	}
+	return newSlidingWindow[T](0, span)
+}
+
+func newSlidingWindow[T Number](size int, span time.Duration) *SlidingWindow[T] {
+	w := &SlidingWindow[T]{
+		size: size,
+		span: span,
+		max:  monotonicQueue[T]{keep: func(old, x T) bool { return cmp.Compare(old, x) > 0 }},
+		min:  monotonicQueue[T]{keep: func(old, x T) bool { return cmp.Compare(old, x) < 0 }},
+	}
+	w.sum = NewAggregate(w, func(x T) T { return x }, Monoid[T]{Combine: func(a, b T) T { return a + b }})
+	return w
+}
+
+// OnEvict设置元素离开窗口时的回调函数，f为nil时取消回调。
+// !!! 回调在Push、PushAt或Expire的过程中被调用，此时窗口的统计量尚未包含新元素。
+func (w *SlidingWindow[T]) OnEvict(f func(x T)) {
+	w.onEvict = f
+}
+
+// Push把x加入窗口。按时间的窗口以当前时间作为x的时间戳。
+func (w *SlidingWindow[T]) Push(x T) {
+	var now time.Time
+	if w.span > 0 {
+		now = time.Now()
+	}
+	w.PushAt(x, now)
+}
+
+// Add等价于Push，使SlidingWindow实现了Sketch接口。
+func (w *SlidingWindow[T]) Add(x T) {
+	w.Push(x)
+}
+
+// PushAt以at作为时间戳把x加入窗口，并淘汰离开窗口的元素。按个数的窗口忽略at。
+// !!! 时间戳应当是非递减的；早于窗口中最近一个元素的时间戳（乱序到达的数据）按最近一个元素的时间戳处理。
+func (w *SlidingWindow[T]) PushAt(x T, at time.Time) {
+	if w.span > 0 {
+		if !w.entries.IsEmpty() {
+			if last := w.entries.Last().at; at.Before(last) {
+				at = last
+			}
+		}
+		w.Expire(at)
+	} else if w.entries.Size() == w.size {
+		w.evict()
+	}
+	w.entries.InsertBack(windowEntry[T]{seq: w.seq, value: x, at: at})
+	w.max.push(w.seq, x)
+	w.min.push(w.seq, x)
+	for _, o := range w.observers {
+		o.push(x)
+	}
+	w.seq++
+}
+
+// Expire淘汰时间戳不晚于now-span的元素，按个数的窗口不受影响。
+func (w *SlidingWindow[T]) Expire(now time.Time) {
+	if w.span <= 0 {
+		return
+	}
+	deadline := now.Add(-w.span)
+	for !w.entries.IsEmpty() && !w.entries.First().at.After(deadline) {
+		w.evict()
+	}
+}
+
+// evict移除窗口中最早的元素
+func (w *SlidingWindow[T]) evict() {
+	e := w.entries.RemoveFirst()
+	w.max.evict(e.seq)
+	w.min.evict(e.seq)
+	for _, o := range w.observers {
+		o.evict()
+	}
+	if w.onEvict != nil {
+		w.onEvict(e.value)
+	}
+}
+
+// Len返回窗口中元素的个数
+func (w *SlidingWindow[T]) Len() int {
+	return w.entries.Size()
+}
+
+// IsEmpty判断窗口是否为空
+func (w *SlidingWindow[T]) IsEmpty() bool {
+	return w.entries.IsEmpty()
+}
+
+// Max返回窗口中的最大值，窗口为空时抛出panic。
+func (w *SlidingWindow[T]) Max() T {
+	if w.IsEmpty() {
+		panic("窗口为空，没有最大值")
+	}
+	return w.max.first()
+}
+
+// Min返回窗口中的最小值，窗口为空时抛出panic。
+func (w *SlidingWindow[T]) Min() T {
+	if w.IsEmpty() {
+		panic("窗口为空，没有最小值")
+	}
+	return w.min.first()
+}
+
+// Sum返回窗口中所有元素的和，窗口为空时返回0。
+// !!! 和由双栈维护而不是“加上新元素、减去旧元素”，因此浮点数的舍入误差不会随着窗口的滑动而累积。
+func (w *SlidingWindow[T]) Sum() T {
+	return w.sum.Value()
+}
+
+// Mean返回窗口中所有元素的平均值，窗口为空时返回NaN。
+func (w *SlidingWindow[T]) Mean() float64 {
+	if w.IsEmpty() {
+		return math.NaN()
+	}
+	return float64(w.Sum()) / float64(w.Len())
+}
+
+// All返回从最早到最近遍历窗口中元素的迭代器
+func (w *SlidingWindow[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range w.entries.All() {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+}
+
+// monotonicQueue是维护窗口最大值（或最小值）的单调双向队列，队头是当前的最值。
+// !!! keep(old, x)为true时，较早的old在x进入窗口后仍可能成为最值，需要保留；
+// !!! 否则old会比x先离开窗口，且不会比x更优，可以淘汰。
+type monotonicQueue[T any] struct {
+	keep  func(old, x T) bool
+	deque basic.RingDeque[windowEntry[T]]
+}
+
+func (q *monotonicQueue[T]) push(seq uint64, x T) {
+	for !q.deque.IsEmpty() && !q.keep(q.deque.Last().value, x) {
+		q.deque.RemoveLast()
+	}
+	q.deque.InsertBack(windowEntry[T]{seq: seq, value: x})
+}
+
+// evict在序号为seq的元素离开窗口时调用，只有它是队头时才需要移除
+func (q *monotonicQueue[T]) evict(seq uint64) {
+	if !q.deque.IsEmpty() && q.deque.First().seq == seq {
+		q.deque.RemoveFirst()
+	}
+}
+
+func (q *monotonicQueue[T]) first() T {
+	return q.deque.First().value
+}
+
+// Monoid（幺半群）是带有单位元的结合运算：Combine(Combine(a, b), c) == Combine(a, Combine(b, c))，
+// 并且Combine(Identity, a) == Combine(a, Identity) == a。Combine不需要满足交换律。
+// !!! 例如：加法与0、乘法与1、max与负无穷、字符串拼接与空串、矩阵乘法与单位矩阵。
+type Monoid[A any] struct {
+	Identity A
+	Combine  func(a, b A) A
+}
+
+// Aggregate在SlidingWindow上按窗口中元素从早到晚的顺序计算Combine(lift(x1), lift(x2), ...)，
+// 元素进入、离开窗口与查询都是均摊O(1)。
+// !!! 这是用两个栈实现队列的技巧（two-stack queue）的推广：新元素压入back栈，同时维护back中所有元素的聚合；
+// !!! 元素从front栈弹出，front中每个位置保存从它到front栈底（即更晚的元素）的聚合，因此弹出后栈顶仍是正确的聚合。
+// !!! front为空时把back中的元素逐个转移到front并计算这些聚合，每个元素最多被转移一次。
+// !!! 整个窗口的聚合就是Combine(front栈顶的聚合, back的聚合)。与“减去离开窗口的元素”不同，它不要求运算可逆。
+type Aggregate[T, A any] struct {
+	lift    func(x T) A
+	monoid  Monoid[A]
+	front   []A // front[len-1]是最早元素及其之后front中所有元素的聚合
+	back    []A // 按进入窗口的顺序保存lift之后的元素
+	backAgg A
+}
+
+// NewAggregate创建一个在w上计算聚合的Aggregate，w中已有的元素也会被计入。
+// !!! Aggregate随w的滑动自动更新，在w的整个生命周期中有效。
+func NewAggregate[T Number, A any](w *SlidingWindow[T], lift func(x T) A, monoid Monoid[A]) *Aggregate[T, A] {
+	a := &Aggregate[T, A]{lift: lift, monoid: monoid, backAgg: monoid.Identity}
+	for x := range w.All() {
+		a.push(x)
+	}
+	w.observers = append(w.observers, a)
+	return a
+}
+
+// Value返回窗口中所有元素的聚合，窗口为空时返回单位元。
+func (a *Aggregate[T, A]) Value() A {
+	if len(a.front) == 0 {
+		return a.backAgg
+	}
+	return a.monoid.Combine(a.front[len(a.front)-1], a.backAgg)
+}
+
+func (a *Aggregate[T, A]) push(x T) {
+	v := a.lift(x)
+	a.back = append(a.back, v)
+	a.backAgg = a.monoid.Combine(a.backAgg, v)
+}
+
+func (a *Aggregate[T, A]) evict() {
+	if len(a.front) == 0 {
+		//!!! 从最晚的元素开始转移，front栈顶就是最早的元素
+		agg := a.monoid.Identity
+		for i := len(a.back) - 1; i >= 0; i-- {
+			agg = a.monoid.Combine(a.back[i], agg)
+			a.front = append(a.front, agg)
+		}
+		clear(a.back)
+		a.back = a.back[:0]
+		a.backAgg = a.monoid.Identity
+	}
+	var zero A
+	a.front[len(a.front)-1] = zero //!!! 清除对已移除元素的引用，以便垃圾回收
+	a.front = a.front[:len(a.front)-1]
+}
@@ -0,0 +1,114 @@
+package stream
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCountWindow(t *testing.T) {
+	for _, k := range []int{1, 2, 7, 100} {
+		w := NewCountWindow[int](k)
+		data := make([]int, 2000)
+		for i := range data {
+			data[i] = rand.IntN(50) - 25 //!!! 取值范围小，有大量重复的元素
+			w.Push(data[i])
+			//!!! 与暴力计算比较，k=7时的最大值序列即MaxSubarrayUsingDeque(data, 7)的结果
+			window := data[max(0, i-k+1) : i+1]
+			sum := 0
+			for _, x := range window {
+				sum += x
+			}
+			if w.Len() != len(window) || w.Max() != slices.Max(window) || w.Min() != slices.Min(window) ||
+				w.Sum() != sum || w.Mean() != float64(sum)/float64(len(window)) {
+				t.Fatalf("k=%d, i=%d: (Len, Max, Min, Sum) = (%d, %d, %d, %d)，应为(%d, %d, %d, %d)", k, i,
+					w.Len(), w.Max(), w.Min(), w.Sum(), len(window), slices.Max(window), slices.Min(window), sum)
+			}
+			if got := slices.Collect(w.All()); !slices.Equal(got, window) {
+				t.Fatalf("k=%d, i=%d: 窗口为%v，应为%v", k, i, got, window)
+			}
+		}
+	}
+}
+
+func TestTimeWindowAndEviction(t *testing.T) {
+	w := NewTimeWindow[float64](10 * time.Second)
+	var evicted []float64
+	w.OnEvict(func(x float64) { evicted = append(evicted, x) })
+	start := time.Unix(1700000000, 0)
+	at := func(sec int) time.Time { return start.Add(time.Duration(sec) * time.Second) }
+
+	w.PushAt(5, at(0))
+	w.PushAt(1, at(3))
+	w.PushAt(3, at(9))
+	if w.Max() != 5 || w.Min() != 1 || w.Len() != 3 {
+		t.Errorf("(Max, Min, Len) = (%v, %v, %d)，应为(5, 1, 3)", w.Max(), w.Min(), w.Len())
+	}
+	//!!! 第10秒时第0秒的元素已过期：窗口是(0, 10]
+	w.PushAt(2, at(10))
+	if w.Max() != 3 || !slices.Equal(evicted, []float64{5}) {
+		t.Errorf("Max = %v，淘汰了%v，应为3与[5]", w.Max(), evicted)
+	}
+	//!!! 乱序到达的元素按第10秒处理
+	w.PushAt(4, at(8))
+	if w.Max() != 4 || w.Len() != 4 {
+		t.Errorf("(Max, Len) = (%v, %d)，应为(4, 4)", w.Max(), w.Len())
+	}
+	w.Expire(at(19))
+	if got := slices.Collect(w.All()); !slices.Equal(got, []float64{2, 4}) || w.Sum() != 6 || w.Mean() != 3 {
+		t.Errorf("窗口为%v，Sum = %v，应为[2 4]与6", got, w.Sum())
+	}
+	w.Expire(at(100))
+	if !w.IsEmpty() || w.Sum() != 0 || !math.IsNaN(w.Mean()) || !slices.Equal(evicted, []float64{5, 1, 3, 2, 4}) {
+		t.Errorf("全部过期后窗口应为空，淘汰的顺序为%v", evicted)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("空窗口的Max应抛出panic")
+		}
+	}()
+	w.Max()
+}
+
+func TestAggregate(t *testing.T) {
+	w := NewCountWindow[int](4)
+	Consume(w, slices.Values([]int{1, 2}))
+	//!!! 字符串拼接不满足交换律，结果必须保持元素的顺序；创建之前已在窗口中的元素也被计入
+	concat := NewAggregate(w, strconv.Itoa, Monoid[string]{Combine: func(a, b string) string { return a + b }})
+	//!!! 平方和用于计算方差
+	squares := NewAggregate(w, func(x int) float64 { return float64(x * x) },
+		Monoid[float64]{Combine: func(a, b float64) float64 { return a + b }})
+	if got := concat.Value(); got != "12" {
+		t.Errorf("Value = %q，应为\"12\"", got)
+	}
+	for x := 3; x <= 9; x++ {
+		w.Push(x)
+		want := ""
+		for y := max(1, x-3); y <= x; y++ {
+			want += strconv.Itoa(y)
+		}
+		if got := concat.Value(); got != want {
+			t.Errorf("Value = %q，应为%q", got, want)
+		}
+		if variance := squares.Value()/float64(w.Len()) - w.Mean()*w.Mean(); w.Len() == 4 && variance != 1.25 {
+			t.Errorf("4个连续整数的方差为%v，应为1.25", variance)
+		}
+	}
+}
+
+func TestWindowFloatSum(t *testing.T) {
+	//!!! 长时间滑动之后和仍然精确：窗口最终只包含1，而之前出现过很大的数
+	w := NewCountWindow[float64](3)
+	for range 1000 {
+		w.Push(1e16)
+		w.Push(1)
+	}
+	w.Push(1)
+	w.Push(1)
+	if w.Sum() != 3 {
+		t.Errorf("Sum = %v，应为3", w.Sum())
+	}
+}
@@ -1,3 +1,3 @@
 module datastructure
 
-go 1.22.3
+go 1.23.0